@@ -11,7 +11,7 @@ func ClosePort(
 	yoloConfig *entities.Config,
 	cluster *entities.Cluster,
 	env *entities.Env,
-	portToClose string,
+	portToClose entities.PortSpec,
 ) error {
 
 	closePortErr := cloudService.ClosePort(
@@ -0,0 +1,87 @@
+package actions
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+// ClosePorts handles all the passed ports with
+// one cloud service call and one config save.
+//
+// The batch is atomic: on error, the ports closed before the error
+// are reopened with their metadata. The ones that fail to be reopened
+// remain recorded as closed in the env so that partial failures can
+// be reported.
+func ClosePorts(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	portsToClose []entities.PortSpec,
+) error {
+
+	openedPorts := map[string]entities.EnvOpenedPort{}
+
+	for _, portToClose := range portsToClose {
+		if openedPort, portOpened := env.OpenedPorts[portToClose.String()]; portOpened {
+			openedPorts[portToClose.String()] = openedPort
+		}
+	}
+
+	closePortsErr := cloudService.ClosePorts(
+		stepper,
+		yoloConfig,
+		cluster,
+		env,
+		portsToClose,
+	)
+
+	if closePortsErr != nil {
+		portsToRollBack := []entities.PortSpec{}
+
+		for _, portToClose := range portsToClose {
+			_, portWasOpened := openedPorts[portToClose.String()]
+
+			if portWasOpened && !env.IsPortOpened(portToClose) {
+				portsToRollBack = append(portsToRollBack, portToClose)
+			}
+		}
+
+		if len(portsToRollBack) > 0 {
+			// The rollback error is not returned given that
+			// the cause of the failure is "closePortsErr"
+			cloudService.OpenPorts(
+				stepper,
+				yoloConfig,
+				cluster,
+				env,
+				portsToRollBack,
+			)
+		}
+
+		// Nothing is done for the ports that failed to be reopened
+		for _, portToRollBack := range portsToRollBack {
+			env.SetOpenedPortMetadata(
+				portToRollBack,
+				openedPorts[portToRollBack.String()].EnvOpenedPortMetadata,
+			)
+		}
+	}
+
+	// "closePortsErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return closePortsErr
+}
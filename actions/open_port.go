@@ -11,7 +11,8 @@ func OpenPort(
 	yoloConfig *entities.Config,
 	cluster *entities.Cluster,
 	env *entities.Env,
-	portToOpen string,
+	portToOpen entities.PortSpec,
+	metadata entities.EnvOpenedPortMetadata,
 ) error {
 
 	openPortErr := cloudService.OpenPort(
@@ -22,6 +23,9 @@ func OpenPort(
 		portToOpen,
 	)
 
+	// Saved along with the opened port
+	env.SetOpenedPortMetadata(portToOpen, metadata)
+
 	// "openPortErr" is not handled first
 	// in order to be able to save partial infrastructure
 	err := UpdateEnvInConfig(
@@ -0,0 +1,83 @@
+package actions
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+// OpenPorts handles all the passed ports with
+// one cloud service call and one config save.
+//
+// The batch is atomic: on error, the ports opened before the error
+// are closed. The ones that fail to be closed remain recorded as
+// opened in the env so that partial failures can be reported.
+func OpenPorts(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	portsToOpen []entities.PortSpec,
+	metadata entities.EnvOpenedPortMetadata,
+) error {
+
+	alreadyOpenedPorts := map[string]bool{}
+
+	for _, portToOpen := range portsToOpen {
+		alreadyOpenedPorts[portToOpen.String()] = env.IsPortOpened(portToOpen)
+	}
+
+	openPortsErr := cloudService.OpenPorts(
+		stepper,
+		yoloConfig,
+		cluster,
+		env,
+		portsToOpen,
+	)
+
+	if openPortsErr != nil {
+		portsToRollBack := []entities.PortSpec{}
+
+		for _, portToOpen := range portsToOpen {
+			if !alreadyOpenedPorts[portToOpen.String()] && env.IsPortOpened(portToOpen) {
+				portsToRollBack = append(portsToRollBack, portToOpen)
+			}
+		}
+
+		if len(portsToRollBack) > 0 {
+			// The rollback error is not returned given that
+			// the cause of the failure is "openPortsErr"
+			cloudService.ClosePorts(
+				stepper,
+				yoloConfig,
+				cluster,
+				env,
+				portsToRollBack,
+			)
+		}
+	}
+
+	// On error, only the ports that remain
+	// opened are recorded in the env
+	for _, portToOpen := range portsToOpen {
+		if !alreadyOpenedPorts[portToOpen.String()] {
+			env.SetOpenedPortMetadata(portToOpen, metadata)
+		}
+	}
+
+	// "openPortsErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return openPortsErr
+}
@@ -0,0 +1,303 @@
+package actions
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+// planningCloudService wraps a cloud service in order to
+// plan all the mutating operations instead of executing them.
+//
+// The read-only operations are forwarded to the wrapped cloud service
+// whereas "SaveYoloConfig" does nothing given that, in plan mode,
+// the config is only modified in memory.
+type planningCloudService struct {
+	cloudService entities.CloudService
+	plan         *entities.Plan
+}
+
+func NewPlanningCloudService(
+	cloudService entities.CloudService,
+	plan *entities.Plan,
+) entities.CloudService {
+
+	return planningCloudService{
+		cloudService: cloudService,
+		plan:         plan,
+	}
+}
+
+func (p planningCloudService) planOperation(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	operation entities.CloudServiceOperation,
+) error {
+
+	plannedOperations, err := p.cloudService.PlanOperation(
+		stepper,
+		yoloConfig,
+		operation,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	p.plan.AddOperations(plannedOperations...)
+
+	return nil
+}
+
+func (p planningCloudService) CreateYoloConfigStorage(
+	stepper stepper.Stepper,
+) error {
+
+	return p.planOperation(stepper, nil, entities.CloudServiceOperation{
+		Type: entities.CloudServiceOperationCreateYoloConfigStorage,
+	})
+}
+
+func (p planningCloudService) RemoveYoloConfigStorage(
+	stepper stepper.Stepper,
+) error {
+
+	return p.planOperation(stepper, nil, entities.CloudServiceOperation{
+		Type: entities.CloudServiceOperationRemoveYoloConfigStorage,
+	})
+}
+
+func (p planningCloudService) LookupYoloConfig(
+	stepper stepper.Stepper,
+) (*entities.Config, error) {
+
+	return p.cloudService.LookupYoloConfig(stepper)
+}
+
+func (p planningCloudService) SaveYoloConfig(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+) error {
+
+	return nil
+}
+
+func (p planningCloudService) CreateCluster(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationCreateCluster,
+		Cluster: cluster,
+	})
+}
+
+func (p planningCloudService) RemoveCluster(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationRemoveCluster,
+		Cluster: cluster,
+	})
+}
+
+func (p planningCloudService) CheckInstanceTypeValidity(
+	stepper stepper.Stepper,
+	instanceType string,
+) error {
+
+	return p.cloudService.CheckInstanceTypeValidity(stepper, instanceType)
+}
+
+func (p planningCloudService) EstimateCost(
+	stepper stepper.Stepper,
+	instanceType string,
+	cluster *entities.Cluster,
+) (*entities.CostEstimate, error) {
+
+	return p.cloudService.EstimateCost(stepper, instanceType, cluster)
+}
+
+func (p planningCloudService) SuggestInstanceType(
+	stepper stepper.Stepper,
+	hostRequirements entities.HostRequirements,
+) (string, error) {
+
+	return p.cloudService.SuggestInstanceType(stepper, hostRequirements)
+}
+
+func (p planningCloudService) CreateEnv(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationCreateEnv,
+		Cluster: cluster,
+		Env:     env,
+	})
+}
+
+func (p planningCloudService) RemoveEnv(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationRemoveEnv,
+		Cluster: cluster,
+		Env:     env,
+	})
+}
+
+func (p planningCloudService) StopEnv(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationStopEnv,
+		Cluster: cluster,
+		Env:     env,
+	})
+}
+
+func (p planningCloudService) StartEnv(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationStartEnv,
+		Cluster: cluster,
+		Env:     env,
+	})
+}
+
+func (p planningCloudService) DescribeEnv(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) (*entities.EnvObservedState, error) {
+
+	return p.cloudService.DescribeEnv(stepper, yoloConfig, cluster, env)
+}
+
+func (p planningCloudService) UpdateEnvSSHKeyPair(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	newSSHKeyPair entities.EnvSSHKeyPair,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationUpdateEnvSSHKeyPair,
+		Cluster: cluster,
+		Env:     env,
+	})
+}
+
+func (p planningCloudService) OpenPort(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	portToOpen entities.PortSpec,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationOpenPort,
+		Cluster: cluster,
+		Env:     env,
+		Port:    portToOpen,
+	})
+}
+
+func (p planningCloudService) ClosePort(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	portToClose entities.PortSpec,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationClosePort,
+		Cluster: cluster,
+		Env:     env,
+		Port:    portToClose,
+	})
+}
+
+func (p planningCloudService) OpenPorts(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	portsToOpen []entities.PortSpec,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationOpenPorts,
+		Cluster: cluster,
+		Env:     env,
+		Ports:   portsToOpen,
+	})
+}
+
+func (p planningCloudService) ClosePorts(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	portsToClose []entities.PortSpec,
+) error {
+
+	return p.planOperation(stepper, yoloConfig, entities.CloudServiceOperation{
+		Type:    entities.CloudServiceOperationClosePorts,
+		Cluster: cluster,
+		Env:     env,
+		Ports:   portsToClose,
+	})
+}
+
+func (p planningCloudService) ListManagedResources(
+	stepper stepper.Stepper,
+) ([]entities.ManagedResource, error) {
+
+	return p.cloudService.ListManagedResources(stepper)
+}
+
+func (p planningCloudService) RemoveManagedResource(
+	stepper stepper.Stepper,
+	resource entities.ManagedResource,
+) error {
+
+	return p.planOperation(stepper, nil, entities.CloudServiceOperation{
+		Type:            entities.CloudServiceOperationRemoveManagedResource,
+		ManagedResource: &resource,
+	})
+}
+
+func (p planningCloudService) PlanOperation(
+	stepper stepper.Stepper,
+	yoloConfig *entities.Config,
+	operation entities.CloudServiceOperation,
+) ([]entities.PlannedOperation, error) {
+
+	return p.cloudService.PlanOperation(stepper, yoloConfig, operation)
+}
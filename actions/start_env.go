@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"time"
+
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+func StartEnv(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+) error {
+
+	env.Status = entities.EnvStatusStarting
+	env.RecordStart(time.Now())
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	startEnvErr := cloudService.StartEnv(
+		stepper,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	// "startEnvErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err = UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if startEnvErr != nil {
+		return startEnvErr
+	}
+
+	env.Status = entities.EnvStatusCreated
+	env.StopReason = ""
+	return UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+}
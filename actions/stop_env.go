@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"time"
+
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+func StopEnv(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	reason entities.EnvStopReason,
+) error {
+
+	env.Status = entities.EnvStatusStopping
+	env.StopReason = reason
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	stopEnvErr := cloudService.StopEnv(
+		stepper,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	// "stopEnvErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err = UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if stopEnvErr != nil {
+		return stopEnvErr
+	}
+
+	env.Status = entities.EnvStatusStopped
+	env.RecordStop(time.Now())
+	return UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+}
@@ -0,0 +1,74 @@
+package actions
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+// UpdateEnvSSHKeyPair installs the passed key pair in the env.
+//
+// The env key pair is only replaced once installed so that
+// the env remains accessible with the old key pair on error.
+//
+// When the config could not be saved once the new key pair is
+// installed, the old key pair is reinstalled. If this also fails,
+// "ErrEnvSSHKeyPairNotPersisted" is returned with the new key pair.
+func UpdateEnvSSHKeyPair(
+	stepper stepper.Stepper,
+	cloudService entities.CloudService,
+	yoloConfig *entities.Config,
+	cluster *entities.Cluster,
+	env *entities.Env,
+	newSSHKeyPair entities.EnvSSHKeyPair,
+) error {
+
+	oldSSHKeyPair := env.GetSSHKeyPair()
+
+	updateKeyPairErr := cloudService.UpdateEnvSSHKeyPair(
+		stepper,
+		yoloConfig,
+		cluster,
+		env,
+		newSSHKeyPair,
+	)
+
+	if updateKeyPairErr == nil {
+		env.SetSSHKeyPair(newSSHKeyPair)
+	}
+
+	// "updateKeyPairErr" is not handled first
+	// in order to be able to save partial infrastructure
+	err := UpdateEnvInConfig(
+		stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil && updateKeyPairErr == nil {
+		// The env is accessed with the new key pair to reinstall the old one
+		rollbackErr := cloudService.UpdateEnvSSHKeyPair(
+			stepper,
+			yoloConfig,
+			cluster,
+			env,
+			oldSSHKeyPair,
+		)
+
+		if rollbackErr != nil {
+			return entities.ErrEnvSSHKeyPairNotPersisted{
+				EnvName:       env.Name,
+				NewSSHKeyPair: newSSHKeyPair,
+			}
+		}
+
+		env.SetSSHKeyPair(oldSSHKeyPair)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return updateKeyPairErr
+}
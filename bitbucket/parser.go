@@ -0,0 +1,109 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	giturls "github.com/whilp/git-urls"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/vcs"
+)
+
+func BuildGitHTTPURL(workspace, repoSlug string) entities.EnvRepositoryGitURL {
+	return entities.EnvRepositoryGitURL(fmt.Sprintf(
+		"https://%s/%s/%s.git",
+		DefaultHost,
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	))
+}
+
+func BuildGitURL(workspace, repoSlug string) entities.EnvRepositoryGitURL {
+	return entities.EnvRepositoryGitURL(fmt.Sprintf(
+		"git@%s:%s/%s.git",
+		DefaultHost,
+		url.PathEscape(workspace),
+		url.PathEscape(repoSlug),
+	))
+}
+
+// ParsedBitbucketRepositoryName represents a parsed Bitbucket repository
+// name. The owner is the workspace and the name is the repository slug.
+type ParsedBitbucketRepositoryName struct {
+	Owner         string
+	ExplicitOwner bool
+	Name          string
+	Variant       string
+}
+
+func ParseRepositoryName(
+	repositoryName string,
+	defaultRepositoryOwner string,
+) (*ParsedBitbucketRepositoryName, error) {
+
+	errInvalidBitbucketURL := errors.New("ErrInvalidBitbucketURL")
+
+	// Handle yolo-sh/yolo@feature-x
+	repositoryName, variant, hasVariant := vcs.SplitRepositoryVariant(repositoryName)
+
+	if hasVariant {
+		err := entities.CheckEnvRepositoryVariantValidity(variant)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Handle git@bitbucket.org:yolo-sh/yolo.git
+	repositoryNameAsURL, err := giturls.Parse(repositoryName)
+
+	if err != nil {
+		// Handle https://bitbucket.org/yolo-sh/yolo.git
+		repositoryNameAsURL, err = url.Parse(repositoryName)
+	}
+
+	// Not an URL (eg: yolo) or only path (eg: yolo-sh/yolo)
+	if err != nil || len(repositoryNameAsURL.Hostname()) == 0 {
+		repositoryNameParts := strings.Split(repositoryName, "/")
+
+		if len(repositoryNameParts) > 2 {
+			return nil, errInvalidBitbucketURL
+		}
+
+		if len(repositoryNameParts) == 1 { // yolo
+			return &ParsedBitbucketRepositoryName{
+				ExplicitOwner: false,
+				Owner:         defaultRepositoryOwner,
+				Name:          repositoryNameParts[0],
+				Variant:       variant,
+			}, nil
+		}
+
+		return &ParsedBitbucketRepositoryName{ // yolo-sh/yolo
+			ExplicitOwner: true,
+			Owner:         repositoryNameParts[0],
+			Name:          repositoryNameParts[1],
+			Variant:       variant,
+		}, nil
+	}
+
+	if !strings.EqualFold(repositoryNameAsURL.Hostname(), DefaultHost) {
+		return nil, errInvalidBitbucketURL
+	}
+
+	path := strings.TrimPrefix(repositoryNameAsURL.Path, "/")
+	pathComponents := strings.Split(path, "/")
+
+	if len(pathComponents) < 2 {
+		return nil, errInvalidBitbucketURL
+	}
+
+	return &ParsedBitbucketRepositoryName{
+		ExplicitOwner: true,
+		Owner:         pathComponents[0],
+		Name:          strings.TrimSuffix(pathComponents[1], ".git"),
+		Variant:       variant,
+	}, nil
+}
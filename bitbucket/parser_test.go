@@ -0,0 +1,139 @@
+package bitbucket
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepositoryName(t *testing.T) {
+	testCases := []struct {
+		test           string
+		repositoryName string
+		expectedParsed *ParsedBitbucketRepositoryName
+	}{
+		{
+			test:           "with name only",
+			repositoryName: "yolo",
+			expectedParsed: &ParsedBitbucketRepositoryName{
+				Owner: "default-owner",
+				Name:  "yolo",
+			},
+		},
+
+		{
+			test:           "with workspace and name",
+			repositoryName: "yolo-sh/yolo",
+			expectedParsed: &ParsedBitbucketRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with git URL",
+			repositoryName: "git@bitbucket.org:yolo-sh/yolo.git",
+			expectedParsed: &ParsedBitbucketRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with HTTP URL to a source",
+			repositoryName: "https://bitbucket.org/yolo-sh/yolo/src/main/",
+			expectedParsed: &ParsedBitbucketRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with HTTP URL and variant",
+			repositoryName: "https://bitbucket.org/yolo-sh/yolo.git@feature-x",
+			expectedParsed: &ParsedBitbucketRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+				Variant:       "feature-x",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			parsed, err := ParseRepositoryName(
+				tc.repositoryName,
+				"default-owner",
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedParsed, parsed) {
+				t.Fatalf(
+					"expected parsed repository name to equal '%+v', got '%+v'",
+					tc.expectedParsed,
+					parsed,
+				)
+			}
+		})
+	}
+}
+
+func TestParseInvalidRepositoryName(t *testing.T) {
+	testCases := []struct {
+		test           string
+		repositoryName string
+	}{
+		{
+			test:           "with non matching host",
+			repositoryName: "https://github.com/yolo-sh/yolo.git",
+		},
+
+		{
+			test:           "with nested path",
+			repositoryName: "yolo-sh/backend/api",
+		},
+
+		{
+			test:           "with missing name",
+			repositoryName: "https://bitbucket.org/yolo-sh",
+		},
+
+		{
+			test:           "with invalid variant",
+			repositoryName: "yolo-sh/yolo@",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseRepositoryName(
+				tc.repositoryName,
+				"default-owner",
+			)
+
+			if err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+		})
+	}
+}
+
+func TestBuildGitURLs(t *testing.T) {
+	gitURL := BuildGitURL("yolo-sh", "yolo")
+
+	if gitURL != "git@bitbucket.org:yolo-sh/yolo.git" {
+		t.Fatalf("expected git URL to equal 'git@bitbucket.org:yolo-sh/yolo.git', got '%s'", gitURL)
+	}
+
+	gitHTTPURL := BuildGitHTTPURL("yolo-sh", "yolo")
+
+	if gitHTTPURL != "https://bitbucket.org/yolo-sh/yolo.git" {
+		t.Fatalf("expected git HTTP URL to equal 'https://bitbucket.org/yolo-sh/yolo.git', got '%s'", gitHTTPURL)
+	}
+}
@@ -0,0 +1,138 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// DefaultHost is the host of Bitbucket Cloud.
+const DefaultHost = "bitbucket.org"
+
+// DefaultAPIBaseURL is the base URL of the Bitbucket Cloud API.
+const DefaultAPIBaseURL = "https://api.bitbucket.org/2.0"
+
+var _ entities.VCSProvider = Service{}
+
+type Service struct {
+	host       string
+	apiBaseURL string
+	httpClient *http.Client
+}
+
+type ServiceOption func(*Service)
+
+// WithAPIBaseURL overrides the base URL of the Bitbucket API.
+func WithAPIBaseURL(apiBaseURL string) ServiceOption {
+	return func(s *Service) {
+		s.apiBaseURL = apiBaseURL
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) ServiceOption {
+	return func(s *Service) {
+		s.httpClient = httpClient
+	}
+}
+
+func NewService(options ...ServiceOption) Service {
+	service := Service{
+		host:       DefaultHost,
+		apiBaseURL: DefaultAPIBaseURL,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(&service)
+	}
+
+	return service
+}
+
+func (s Service) Host() string {
+	return s.host
+}
+
+// do sends a request to the Bitbucket API and decodes the JSON response
+// in "response" (if not nil). The passed path must be URL-escaped.
+func (s Service) do(
+	accessToken string,
+	method string,
+	path string,
+	body interface{},
+	response interface{},
+) error {
+
+	responseBody, err := s.doRaw(accessToken, method, path, body)
+
+	if err != nil {
+		return err
+	}
+
+	if response == nil || len(responseBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, response)
+}
+
+func (s Service) doRaw(
+	accessToken string,
+	method string,
+	path string,
+	body interface{},
+) ([]byte, error) {
+
+	var requestBody io.Reader
+
+	if body != nil {
+		encodedBody, err := json.Marshal(body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		requestBody = bytes.NewReader(encodedBody)
+	}
+
+	req, err := http.NewRequest(
+		method,
+		strings.TrimSuffix(s.apiBaseURL, "/")+path,
+		requestBody,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newErrorResponse(resp.StatusCode, responseBody)
+	}
+
+	return responseBody, nil
+}
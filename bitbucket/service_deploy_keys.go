@@ -0,0 +1,183 @@
+package bitbucket
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type DeployKey struct {
+	ID    int64  `json:"id"`
+	Label string `json:"label"`
+	Key   string `json:"key"`
+}
+
+// CreateDeployKey registers the passed key on the repository
+// only, unlike "CreateSSHKey" that registers it on the user account.
+//
+// Bitbucket deploy keys are always read-only so the read-write
+// ones are rejected (see "SupportsReadWriteDeployKeys").
+func (s Service) CreateDeployKey(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+	keyPairName string,
+	publicKeyContent string,
+	readOnly bool,
+) (*DeployKey, error) {
+
+	if !readOnly {
+		return nil, entities.ErrReadWriteDeployKeyNotSupported{
+			VCSProviderHost: s.host,
+		}
+	}
+
+	var key DeployKey
+
+	err := s.do(
+		accessToken,
+		http.MethodPost,
+		repositoryPath(workspace, repoSlug)+"/deploy-keys",
+		map[string]string{
+			"label": keyPairName,
+			"key":   publicKeyContent,
+		},
+		&key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s Service) ListDeployKeys(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+) ([]DeployKey, error) {
+
+	keys := []DeployKey{}
+
+	for page := 1; ; page++ {
+		var keysPage struct {
+			Values []DeployKey `json:"values"`
+			Next   string      `json:"next"`
+		}
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			repositoryPath(workspace, repoSlug)+
+				"/deploy-keys?pagelen="+strconv.Itoa(keysPerPage)+
+				"&page="+strconv.Itoa(page),
+			nil,
+			&keysPage,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage.Values...)
+
+		if len(keysPage.Next) == 0 {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+func (s Service) RemoveDeployKey(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+	deployKeyID int64,
+) error {
+
+	return s.do(
+		accessToken,
+		http.MethodDelete,
+		repositoryPath(workspace, repoSlug)+
+			"/deploy-keys/"+strconv.FormatInt(deployKeyID, 10),
+		nil,
+		nil,
+	)
+}
+
+func (s Service) SupportsReadWriteDeployKeys() bool {
+	return false
+}
+
+func (s Service) RegisterDeployKey(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+	keyTitle string,
+	publicKeyContent string,
+	readOnly bool,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateDeployKey(
+		accessToken,
+		workspace,
+		repoSlug,
+		keyTitle,
+		publicKeyContent,
+		readOnly,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVCSKeyFromDeployKey(*key), nil
+}
+
+func (s Service) ListRegisteredDeployKeys(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+) ([]entities.VCSKey, error) {
+
+	keys, err := s.ListDeployKeys(accessToken, workspace, repoSlug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, *buildVCSKeyFromDeployKey(key))
+	}
+
+	return vcsKeys, nil
+}
+
+func (s Service) UnregisterDeployKey(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+	keyID string,
+) error {
+
+	deployKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveDeployKey(accessToken, workspace, repoSlug, deployKeyID)
+}
+
+func buildVCSKeyFromDeployKey(key DeployKey) *entities.VCSKey {
+	return &entities.VCSKey{
+		ID:       strconv.FormatInt(key.ID, 10),
+		Title:    key.Label,
+		ReadOnly: true,
+	}
+}
@@ -0,0 +1,57 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ErrorResponse struct {
+	StatusCode int
+	Message    string
+}
+
+func newErrorResponse(statusCode int, body []byte) *ErrorResponse {
+	var decodedBody struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	message := string(body)
+
+	if err := json.Unmarshal(body, &decodedBody); err == nil &&
+		len(decodedBody.Error.Message) > 0 {
+
+		message = decodedBody.Error.Message
+	}
+
+	return &ErrorResponse{
+		StatusCode: statusCode,
+		Message:    message,
+	}
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("Bitbucket API error (%d): %s", e.StatusCode, e.Message)
+}
+
+func (s Service) IsNotFoundError(err error) bool {
+	if bitbucketErr, ok := err.(*ErrorResponse); ok &&
+		bitbucketErr.StatusCode == http.StatusNotFound {
+
+		return true
+	}
+
+	return false
+}
+
+func (s Service) IsInvalidAccessTokenError(err error) bool {
+	if bitbucketErr, ok := err.(*ErrorResponse); ok &&
+		bitbucketErr.StatusCode == http.StatusUnauthorized {
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type GPGKey struct {
+	Fingerprint string `json:"fingerprint"`
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+}
+
+func (s Service) CreateGPGKey(
+	accessToken string,
+	keyName string,
+	publicKeyContent string,
+) (*GPGKey, error) {
+
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var key GPGKey
+
+	err = s.do(
+		accessToken,
+		http.MethodPost,
+		"/users/"+url.PathEscape(user.UUID)+"/gpg-keys",
+		map[string]string{
+			"name": keyName,
+			"key":  publicKeyContent,
+		},
+		&key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// RemoveGPGKey removes the GPG key identified by the passed
+// fingerprint given that Bitbucket doesn't assign IDs to GPG keys.
+func (s Service) RemoveGPGKey(
+	accessToken string,
+	gpgKeyFingerprint string,
+) error {
+
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return err
+	}
+
+	return s.do(
+		accessToken,
+		http.MethodDelete,
+		"/users/"+url.PathEscape(user.UUID)+"/gpg-keys/"+url.PathEscape(gpgKeyFingerprint),
+		nil,
+		nil,
+	)
+}
+
+func (s Service) RegisterGPGKey(
+	accessToken string,
+	keyTitle string,
+	publicKeyContent string,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateGPGKey(accessToken, keyTitle, publicKeyContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.VCSKey{
+		ID:    key.Fingerprint,
+		Title: key.Name,
+	}, nil
+}
+
+func (s Service) UnregisterGPGKey(
+	accessToken string,
+	keyID string,
+) error {
+
+	return s.RemoveGPGKey(accessToken, keyID)
+}
+
+func (s Service) ListGPGKeys(accessToken string) ([]GPGKey, error) {
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []GPGKey{}
+
+	for page := 1; ; page++ {
+		var keysPage struct {
+			Values []GPGKey `json:"values"`
+			Next   string   `json:"next"`
+		}
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			"/users/"+url.PathEscape(user.UUID)+"/gpg-keys?pagelen="+
+				strconv.Itoa(keysPerPage)+"&page="+strconv.Itoa(page),
+			nil,
+			&keysPage,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage.Values...)
+
+		if len(keysPage.Next) == 0 {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+func (s Service) ListRegisteredGPGKeys(accessToken string) ([]entities.VCSKey, error) {
+	keys, err := s.ListGPGKeys(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, entities.VCSKey{
+			ID:    key.Fingerprint,
+			Title: key.Name,
+		})
+	}
+
+	return vcsKeys, nil
+}
@@ -0,0 +1,109 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/vcs"
+)
+
+// Branches and tags share the same structure
+type namedRef struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+type commit struct {
+	Hash string `json:"hash"`
+}
+
+// ResolveRepositoryRef resolves the passed ref (branch, tag or commit)
+// to a commit SHA. The main branch is used when the ref is empty.
+//
+// Like in Git, tags take precedence over branches with the same name.
+func (s Service) ResolveRepositoryRef(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+	ref string,
+) (*entities.ResolvedEnvRepositoryRef, error) {
+
+	if len(ref) == 0 {
+		repository, err := s.GetRepository(accessToken, workspace, repoSlug)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ref = repository.MainBranch.Name
+	}
+
+	errRefNotFound := entities.ErrEnvRepositoryRefNotFound{
+		RepoOwner: workspace,
+		RepoName:  repoSlug,
+		Ref:       ref,
+	}
+
+	refTypes := []struct {
+		refType entities.EnvRepositoryRefType
+		path    string
+	}{
+		{entities.EnvRepositoryRefTypeTag, "/refs/tags/"},
+		{entities.EnvRepositoryRefTypeBranch, "/refs/branches/"},
+	}
+
+	for _, refType := range refTypes {
+		var resolvedRef namedRef
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			repositoryPath(workspace, repoSlug)+refType.path+url.PathEscape(ref),
+			nil,
+			&resolvedRef,
+		)
+
+		if err != nil && !s.IsNotFoundError(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			return &entities.ResolvedEnvRepositoryRef{
+				Ref:       ref,
+				RefType:   refType.refType,
+				CommitSHA: resolvedRef.Target.Hash,
+			}, nil
+		}
+	}
+
+	if !vcs.IsCommitSHA(ref) {
+		return nil, errRefNotFound
+	}
+
+	var resolvedCommit commit
+
+	err := s.do(
+		accessToken,
+		http.MethodGet,
+		repositoryPath(workspace, repoSlug)+"/commit/"+url.PathEscape(ref),
+		nil,
+		&resolvedCommit,
+	)
+
+	if s.IsNotFoundError(err) {
+		return nil, errRefNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ResolvedEnvRepositoryRef{
+		Ref:       ref,
+		RefType:   entities.EnvRepositoryRefTypeCommit,
+		CommitSHA: resolvedCommit.Hash,
+	}, nil
+}
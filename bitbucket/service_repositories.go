@@ -0,0 +1,190 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type Repository struct {
+	UUID       string `json:"uuid"`
+	FullName   string `json:"full_name"`
+	Language   string `json:"language"`
+	Size       int64  `json:"size"`
+	MainBranch struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+}
+
+func repositoryPath(workspace, repoSlug string) string {
+	return "/repositories/" + url.PathEscape(workspace) + "/" + url.PathEscape(repoSlug)
+}
+
+func (s Service) GetRepository(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+) (*Repository, error) {
+
+	var repository Repository
+
+	err := s.do(
+		accessToken,
+		http.MethodGet,
+		repositoryPath(workspace, repoSlug),
+		nil,
+		&repository,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+func (s Service) DoesRepositoryExist(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+) (bool, error) {
+
+	_, err := s.GetRepository(accessToken, workspace, repoSlug)
+
+	if s.IsNotFoundError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s Service) GetFileContentFromRepository(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+	filePath string,
+	ref string,
+) (string, error) {
+
+	if len(ref) == 0 {
+		repository, err := s.GetRepository(accessToken, workspace, repoSlug)
+
+		if err != nil {
+			return "", err
+		}
+
+		ref = repository.MainBranch.Name
+	}
+
+	fileContent, err := s.doRaw(
+		accessToken,
+		http.MethodGet,
+		repositoryPath(workspace, repoSlug)+
+			"/src/"+url.PathEscape(ref)+
+			"/"+(&url.URL{Path: filePath}).EscapedPath(),
+		nil,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(fileContent), nil
+}
+
+// GetLanguagesUsedInRepository returns the main language of the
+// repository given that Bitbucket doesn't detect the other ones.
+func (s Service) GetLanguagesUsedInRepository(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+) ([]string, error) {
+
+	languages, err := s.GetLanguagesBreakdown(accessToken, workspace, repoSlug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.RepositoryLanguageNames(languages), nil
+}
+
+// GetLanguagesBreakdown returns the main language of the repository
+// as the only language given that Bitbucket doesn't detect the other ones.
+func (s Service) GetLanguagesBreakdown(
+	accessToken string,
+	workspace string,
+	repoSlug string,
+) ([]entities.RepositoryLanguage, error) {
+
+	repository, err := s.GetRepository(accessToken, workspace, repoSlug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLanguagesBreakdown(repository), nil
+}
+
+func buildLanguagesBreakdown(repository *Repository) []entities.RepositoryLanguage {
+	if len(repository.Language) == 0 {
+		return []entities.RepositoryLanguage{}
+	}
+
+	return entities.NewRepositoryLanguagesFromPercentages(map[string]float64{
+		repository.Language: 100,
+	})
+}
+
+func (s Service) ResolveRepository(
+	accessToken string,
+	repositoryName string,
+	defaultRepositoryOwner string,
+) (*entities.ResolvedEnvRepository, error) {
+
+	parsedRepositoryName, err := ParseRepositoryName(
+		repositoryName,
+		defaultRepositoryOwner,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := s.GetRepository(
+		accessToken,
+		parsedRepositoryName.Owner,
+		parsedRepositoryName.Name,
+	)
+
+	if s.IsNotFoundError(err) {
+		return nil, entities.ErrEnvRepositoryNotFound{
+			RepoOwner: parsedRepositoryName.Owner,
+			RepoName:  parsedRepositoryName.Name,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	languages := buildLanguagesBreakdown(repository)
+
+	return &entities.ResolvedEnvRepository{
+		Host:          s.host,
+		Name:          parsedRepositoryName.Name,
+		Owner:         parsedRepositoryName.Owner,
+		ExplicitOwner: parsedRepositoryName.ExplicitOwner,
+		GitURL:        BuildGitURL(parsedRepositoryName.Owner, parsedRepositoryName.Name),
+		GitHTTPURL:    BuildGitHTTPURL(parsedRepositoryName.Owner, parsedRepositoryName.Name),
+		LanguagesUsed: entities.RepositoryLanguageNames(languages),
+		Languages:     languages,
+		SizeBytes:     repository.Size,
+		Variant:       parsedRepositoryName.Variant,
+	}, nil
+}
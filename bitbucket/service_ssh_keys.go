@@ -0,0 +1,156 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type Key struct {
+	UUID  string `json:"uuid"`
+	Label string `json:"label"`
+	Key   string `json:"key"`
+}
+
+func (s Service) CreateSSHKey(
+	accessToken string,
+	keyPairName string,
+	publicKeyContent string,
+) (*Key, error) {
+
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var key Key
+
+	err = s.do(
+		accessToken,
+		http.MethodPost,
+		"/users/"+url.PathEscape(user.UUID)+"/ssh-keys",
+		map[string]string{
+			"label": keyPairName,
+			"key":   publicKeyContent,
+		},
+		&key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s Service) RemoveSSHKey(
+	accessToken string,
+	sshKeyUUID string,
+) error {
+
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return err
+	}
+
+	return s.do(
+		accessToken,
+		http.MethodDelete,
+		"/users/"+url.PathEscape(user.UUID)+"/ssh-keys/"+url.PathEscape(sshKeyUUID),
+		nil,
+		nil,
+	)
+}
+
+func (s Service) SupportsUserKeys() bool {
+	return true
+}
+
+func (s Service) RegisterSSHKey(
+	accessToken string,
+	keyTitle string,
+	publicKeyContent string,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateSSHKey(accessToken, keyTitle, publicKeyContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.VCSKey{
+		ID:    key.UUID,
+		Title: key.Label,
+	}, nil
+}
+
+func (s Service) UnregisterSSHKey(
+	accessToken string,
+	keyID string,
+) error {
+
+	return s.RemoveSSHKey(accessToken, keyID)
+}
+
+const keysPerPage = 100
+
+func (s Service) ListSSHKeys(accessToken string) ([]Key, error) {
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []Key{}
+
+	for page := 1; ; page++ {
+		var keysPage struct {
+			Values []Key  `json:"values"`
+			Next   string `json:"next"`
+		}
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			"/users/"+url.PathEscape(user.UUID)+"/ssh-keys?pagelen="+
+				strconv.Itoa(keysPerPage)+"&page="+strconv.Itoa(page),
+			nil,
+			&keysPage,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage.Values...)
+
+		if len(keysPage.Next) == 0 {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+func (s Service) ListRegisteredSSHKeys(accessToken string) ([]entities.VCSKey, error) {
+	keys, err := s.ListSSHKeys(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, entities.VCSKey{
+			ID:    key.UUID,
+			Title: key.Label,
+		})
+	}
+
+	return vcsKeys, nil
+}
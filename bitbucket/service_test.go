@@ -0,0 +1,550 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+const (
+	testCommitSHA    = "4f2c1e0d9b8a7c6e5f4d3c2b1a0f9e8d7c6b5a49"
+	testTagCommitSHA = "9e8d7c6b5a494f2c1e0d9b8a7c6e5f4d3c2b1a0f"
+)
+
+func newTestService(t *testing.T) Service {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"message": "Access token expired"},
+			})
+			return
+		}
+
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /2.0/repositories/yolo-sh/yolo":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"uuid":       "{repository-uuid}",
+				"full_name":  "yolo-sh/yolo",
+				"language":   "go",
+				"size":       4096,
+				"mainbranch": map[string]string{"name": "main"},
+			})
+		case "GET /2.0/repositories/yolo-sh/yolo/src/main/.yolo.yml":
+			w.Write([]byte("instance_type: t2.medium"))
+		case "GET /2.0/repositories/yolo-sh/yolo/src/" + testCommitSHA + "/.yolo.yml":
+			w.Write([]byte("instance_type: t2.large"))
+		// The tag shadows the branch with the same name
+		case "GET /2.0/repositories/yolo-sh/yolo/refs/tags/v1.0.0":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"name":   "v1.0.0",
+				"target": map[string]string{"hash": testTagCommitSHA},
+			})
+		case "GET /2.0/repositories/yolo-sh/yolo/refs/branches/v1.0.0",
+			"GET /2.0/repositories/yolo-sh/yolo/refs/branches/main":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"name":   "main",
+				"target": map[string]string{"hash": testCommitSHA},
+			})
+		case "GET /2.0/repositories/yolo-sh/yolo/commit/4f2c1e0":
+			json.NewEncoder(w).Encode(map[string]string{
+				"hash": testCommitSHA,
+			})
+		case "GET /2.0/repositories/yolo-sh/docs":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"full_name":  "yolo-sh/docs",
+				"language":   "",
+				"mainbranch": map[string]string{"name": "main"},
+			})
+		case "GET /2.0/user":
+			json.NewEncoder(w).Encode(map[string]string{
+				"uuid":         "{user-uuid}",
+				"username":     "yolo-user",
+				"display_name": "Yolo",
+			})
+		case "POST /2.0/users/%7Buser-uuid%7D/ssh-keys":
+			var key map[string]string
+			json.NewDecoder(r.Body).Decode(&key)
+
+			key["uuid"] = "{ssh-key-uuid}"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(key)
+		case "DELETE /2.0/users/%7Buser-uuid%7D/ssh-keys/%7Bssh-key-uuid%7D":
+			w.WriteHeader(http.StatusNoContent)
+		// Paginated in two pages
+		case "GET /2.0/users/%7Buser-uuid%7D/ssh-keys":
+			if r.URL.Query().Get("page") == "2" {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"values": []map[string]string{
+						{"uuid": "{ssh-key-2}", "label": "yolo-yolo-sh-api-key-pair"},
+					},
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"values": []map[string]string{
+					{"uuid": "{ssh-key-1}", "label": "yolo-yolo-sh-yolo-key-pair"},
+				},
+				"next": "https://api.bitbucket.org/2.0/users/%7Buser-uuid%7D/ssh-keys?page=2",
+			})
+		case "POST /2.0/users/%7Buser-uuid%7D/gpg-keys":
+			var key map[string]string
+			json.NewDecoder(r.Body).Decode(&key)
+
+			key["fingerprint"] = "3262EFF25BA0D270"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(key)
+		case "GET /2.0/users/%7Buser-uuid%7D/gpg-keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"values": []map[string]string{
+					{"fingerprint": "3262EFF25BA0D270", "name": "yolo-yolo-sh-yolo-key-pair"},
+				},
+			})
+		case "DELETE /2.0/users/%7Buser-uuid%7D/gpg-keys/3262EFF25BA0D270":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /2.0/repositories/yolo-sh/yolo/deploy-keys":
+			var key map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&key)
+
+			key["id"] = 42
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(key)
+		case "GET /2.0/repositories/yolo-sh/yolo/deploy-keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"values": []map[string]interface{}{
+					{"id": 42, "label": "yolo-yolo-sh-yolo-key-pair"},
+				},
+			})
+		case "DELETE /2.0/repositories/yolo-sh/yolo/deploy-keys/42":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"message": "Resource not found"},
+			})
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewService(
+		WithAPIBaseURL(server.URL + "/2.0"),
+	)
+}
+
+func TestServiceResolveRepositoryRef(t *testing.T) {
+	service := newTestService(t)
+
+	testCases := []struct {
+		test        string
+		ref         string
+		expectedRef *entities.ResolvedEnvRepositoryRef
+	}{
+		{
+			test: "with empty ref",
+			ref:  "",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "main",
+				RefType:   entities.EnvRepositoryRefTypeBranch,
+				CommitSHA: testCommitSHA,
+			},
+		},
+
+		{
+			test: "with tag shadowing branch",
+			ref:  "v1.0.0",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "v1.0.0",
+				RefType:   entities.EnvRepositoryRefTypeTag,
+				CommitSHA: testTagCommitSHA,
+			},
+		},
+
+		{
+			test: "with abbreviated commit SHA",
+			ref:  "4f2c1e0",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "4f2c1e0",
+				RefType:   entities.EnvRepositoryRefTypeCommit,
+				CommitSHA: testCommitSHA,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			resolvedRef, err := service.ResolveRepositoryRef(
+				"access_token",
+				"yolo-sh",
+				"yolo",
+				tc.ref,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedRef, resolvedRef) {
+				t.Fatalf("expected ref to equal '%+v', got '%+v'", tc.expectedRef, resolvedRef)
+			}
+		})
+	}
+
+	for _, ref := range []string{"unknown", "abcdef0"} {
+		_, err := service.ResolveRepositoryRef(
+			"access_token",
+			"yolo-sh",
+			"yolo",
+			ref,
+		)
+
+		if _, ok := err.(entities.ErrEnvRepositoryRefNotFound); !ok {
+			t.Fatalf("expected ref not found error for '%s', got '%+v'", ref, err)
+		}
+	}
+}
+
+func TestServiceGetFileContentFromRepository(t *testing.T) {
+	service := newTestService(t)
+
+	testCases := []struct {
+		test            string
+		ref             string
+		expectedContent string
+	}{
+		{
+			test:            "with main branch",
+			ref:             "",
+			expectedContent: "instance_type: t2.medium",
+		},
+
+		{
+			test:            "with pinned commit",
+			ref:             testCommitSHA,
+			expectedContent: "instance_type: t2.large",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			fileContent, err := service.GetFileContentFromRepository(
+				"access_token",
+				"yolo-sh",
+				"yolo",
+				".yolo.yml",
+				tc.ref,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if fileContent != tc.expectedContent {
+				t.Fatalf("expected file content to equal '%s', got '%s'", tc.expectedContent, fileContent)
+			}
+		})
+	}
+
+	_, err := service.GetFileContentFromRepository(
+		"access_token",
+		"yolo-sh",
+		"yolo",
+		"unknown.yml",
+		"",
+	)
+
+	if !service.IsNotFoundError(err) {
+		t.Fatalf("expected not found error, got '%+v'", err)
+	}
+}
+
+func TestServiceResolveRepository(t *testing.T) {
+	service := newTestService(t)
+
+	resolvedRepository, err := service.ResolveRepository(
+		"access_token",
+		"git@bitbucket.org:yolo-sh/yolo.git",
+		"default-owner",
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedResolvedRepository := &entities.ResolvedEnvRepository{
+		Host:          "bitbucket.org",
+		Name:          "yolo",
+		Owner:         "yolo-sh",
+		ExplicitOwner: true,
+		GitURL:        "git@bitbucket.org:yolo-sh/yolo.git",
+		GitHTTPURL:    "https://bitbucket.org/yolo-sh/yolo.git",
+		LanguagesUsed: []string{"go"},
+		Languages: []entities.RepositoryLanguage{
+			{Name: "go", Percentage: 100},
+		},
+		SizeBytes: 4096,
+	}
+
+	if !reflect.DeepEqual(expectedResolvedRepository, resolvedRepository) {
+		t.Fatalf(
+			"expected resolved repository to equal '%+v', got '%+v'",
+			expectedResolvedRepository,
+			resolvedRepository,
+		)
+	}
+
+	_, err = service.ResolveRepository(
+		"access_token",
+		"yolo-sh/unknown",
+		"default-owner",
+	)
+
+	if _, ok := err.(entities.ErrEnvRepositoryNotFound); !ok {
+		t.Fatalf("expected repository not found error, got '%+v'", err)
+	}
+
+	_, err = service.ResolveRepository(
+		"invalid_access_token",
+		"yolo-sh/yolo",
+		"default-owner",
+	)
+
+	if !service.IsInvalidAccessTokenError(err) {
+		t.Fatalf("expected invalid access token error, got '%+v'", err)
+	}
+}
+
+func TestServiceDoesRepositoryExist(t *testing.T) {
+	service := newTestService(t)
+
+	exists, err := service.DoesRepositoryExist("access_token", "yolo-sh", "yolo")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !exists {
+		t.Fatalf("expected repository to exist")
+	}
+
+	exists, err = service.DoesRepositoryExist("access_token", "yolo-sh", "unknown")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if exists {
+		t.Fatalf("expected repository to not exist")
+	}
+}
+
+func TestServiceGetAuthenticatedUsername(t *testing.T) {
+	service := newTestService(t)
+
+	username, err := service.GetAuthenticatedUsername("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if username != "yolo-user" {
+		t.Fatalf("expected username to equal 'yolo-user', got '%s'", username)
+	}
+}
+
+func TestServiceGetLanguagesUsedInRepository(t *testing.T) {
+	service := newTestService(t)
+
+	testCases := []struct {
+		test              string
+		repoSlug          string
+		expectedLanguages []string
+	}{
+		{
+			test:              "with main language",
+			repoSlug:          "yolo",
+			expectedLanguages: []string{"go"},
+		},
+
+		{
+			test:              "without language",
+			repoSlug:          "docs",
+			expectedLanguages: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			languages, err := service.GetLanguagesUsedInRepository(
+				"access_token",
+				"yolo-sh",
+				tc.repoSlug,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedLanguages, languages) {
+				t.Fatalf("expected languages to equal '%+v', got '%+v'", tc.expectedLanguages, languages)
+			}
+		})
+	}
+}
+
+func TestServiceSSHKeys(t *testing.T) {
+	service := newTestService(t)
+
+	key, err := service.RegisterSSHKey("access_token", "yolo-key", "ssh-ed25519 AAAA")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedKey := &entities.VCSKey{
+		ID:    "{ssh-key-uuid}",
+		Title: "yolo-key",
+	}
+
+	if !reflect.DeepEqual(expectedKey, key) {
+		t.Fatalf("expected key to equal '%+v', got '%+v'", expectedKey, key)
+	}
+
+	err = service.UnregisterSSHKey("access_token", key.ID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = service.UnregisterSSHKey("access_token", "{unknown}")
+
+	if !service.IsNotFoundError(err) {
+		t.Fatalf("expected not found error, got '%+v'", err)
+	}
+}
+
+func TestServiceListRegisteredSSHKeysWithPagination(t *testing.T) {
+	service := newTestService(t)
+
+	keys, err := service.ListRegisteredSSHKeys("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedKeys := []entities.VCSKey{
+		{ID: "{ssh-key-1}", Title: "yolo-yolo-sh-yolo-key-pair"},
+		{ID: "{ssh-key-2}", Title: "yolo-yolo-sh-api-key-pair"},
+	}
+
+	if !reflect.DeepEqual(expectedKeys, keys) {
+		t.Fatalf("expected keys to equal '%+v', got '%+v'", expectedKeys, keys)
+	}
+}
+
+func TestServiceGPGKeys(t *testing.T) {
+	service := newTestService(t)
+
+	key, err := service.RegisterGPGKey("access_token", "yolo-yolo-sh-yolo-key-pair", "-----BEGIN PGP PUBLIC KEY BLOCK-----")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedKey := &entities.VCSKey{
+		ID:    "3262EFF25BA0D270",
+		Title: "yolo-yolo-sh-yolo-key-pair",
+	}
+
+	if !reflect.DeepEqual(expectedKey, key) {
+		t.Fatalf("expected key to equal '%+v', got '%+v'", expectedKey, key)
+	}
+
+	keys, err := service.ListRegisteredGPGKeys("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual([]entities.VCSKey{*expectedKey}, keys) {
+		t.Fatalf("expected keys to equal '%+v', got '%+v'", []entities.VCSKey{*expectedKey}, keys)
+	}
+
+	err = service.UnregisterGPGKey("access_token", key.ID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestServiceDeployKeys(t *testing.T) {
+	service := newTestService(t)
+
+	readOnly := true
+	key, err := service.RegisterDeployKey(
+		"access_token",
+		"yolo-sh",
+		"yolo",
+		"yolo-yolo-sh-yolo-key-pair",
+		"ssh-ed25519 AAAA",
+		readOnly,
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedKey := &entities.VCSKey{
+		ID:       "42",
+		Title:    "yolo-yolo-sh-yolo-key-pair",
+		ReadOnly: true,
+	}
+
+	if !reflect.DeepEqual(expectedKey, key) {
+		t.Fatalf("expected key to equal '%+v', got '%+v'", expectedKey, key)
+	}
+
+	keys, err := service.ListRegisteredDeployKeys("access_token", "yolo-sh", "yolo")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual([]entities.VCSKey{*expectedKey}, keys) {
+		t.Fatalf("expected keys to equal '%+v', got '%+v'", []entities.VCSKey{*expectedKey}, keys)
+	}
+
+	err = service.UnregisterDeployKey("access_token", "yolo-sh", "yolo", key.ID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestServiceReadWriteDeployKeys(t *testing.T) {
+	service := newTestService(t)
+
+	if service.SupportsReadWriteDeployKeys() {
+		t.Fatalf("expected read-write deploy keys to not be supported")
+	}
+
+	readOnly := false
+	_, err := service.RegisterDeployKey(
+		"access_token",
+		"yolo-sh",
+		"yolo",
+		"yolo-yolo-sh-yolo-key-pair",
+		"ssh-ed25519 AAAA",
+		readOnly,
+	)
+
+	if _, ok := err.(entities.ErrReadWriteDeployKeyNotSupported); !ok {
+		t.Fatalf("expected read-write deploy key not supported error, got '%+v'", err)
+	}
+}
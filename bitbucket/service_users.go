@@ -0,0 +1,37 @@
+package bitbucket
+
+import "net/http"
+
+type User struct {
+	UUID        string `json:"uuid"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+}
+
+func (s Service) GetAuthenticatedUser(accessToken string) (*User, error) {
+	var user User
+
+	err := s.do(
+		accessToken,
+		http.MethodGet,
+		"/user",
+		nil,
+		&user,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (s Service) GetAuthenticatedUsername(accessToken string) (string, error) {
+	user, err := s.GetAuthenticatedUser(accessToken)
+
+	if err != nil {
+		return "", err
+	}
+
+	return user.Username, nil
+}
@@ -13,12 +13,25 @@ type CloudService interface {
 	RemoveCluster(stepper.Stepper, *Config, *Cluster) error
 
 	CheckInstanceTypeValidity(stepper.Stepper, string) error
+	EstimateCost(stepper.Stepper, string, *Cluster) (*CostEstimate, error)
+	SuggestInstanceType(stepper.Stepper, HostRequirements) (string, error)
 
 	CreateEnv(stepper.Stepper, *Config, *Cluster, *Env) error
 	RemoveEnv(stepper.Stepper, *Config, *Cluster, *Env) error
+	StopEnv(stepper.Stepper, *Config, *Cluster, *Env) error
+	StartEnv(stepper.Stepper, *Config, *Cluster, *Env) error
+	DescribeEnv(stepper.Stepper, *Config, *Cluster, *Env) (*EnvObservedState, error)
+	UpdateEnvSSHKeyPair(stepper.Stepper, *Config, *Cluster, *Env, EnvSSHKeyPair) error
 
-	OpenPort(stepper.Stepper, *Config, *Cluster, *Env, string) error
-	ClosePort(stepper.Stepper, *Config, *Cluster, *Env, string) error
+	OpenPort(stepper.Stepper, *Config, *Cluster, *Env, PortSpec) error
+	ClosePort(stepper.Stepper, *Config, *Cluster, *Env, PortSpec) error
+	OpenPorts(stepper.Stepper, *Config, *Cluster, *Env, []PortSpec) error
+	ClosePorts(stepper.Stepper, *Config, *Cluster, *Env, []PortSpec) error
+
+	ListManagedResources(stepper.Stepper) ([]ManagedResource, error)
+	RemoveManagedResource(stepper.Stepper, ManagedResource) error
+
+	PlanOperation(stepper.Stepper, *Config, CloudServiceOperation) ([]PlannedOperation, error)
 }
 
 type CloudServiceBuilder interface {
@@ -29,6 +29,8 @@ type Cluster struct {
 	IsDefault           bool            `json:"is_default"`
 	Status              ClusterStatus   `json:"status"`
 	CreatedAtTimestamp  int64           `json:"created_at_timestamp"`
+	IdlePolicy          *IdlePolicy     `json:"idle_policy"`
+	Schedule            *Schedule       `json:"schedule"`
 }
 
 func NewCluster(
@@ -10,6 +10,7 @@ type Config struct {
 	ID                 string              `json:"id"`
 	Clusters           map[string]*Cluster `json:"clusters"`
 	CreatedAtTimestamp int64               `json:"created_at_timestamp"`
+	InstanceTypeRules  []InstanceTypeRule  `json:"instance_type_rules"`
 }
 
 func NewConfig() *Config {
@@ -19,3 +20,13 @@ func NewConfig() *Config {
 		CreatedAtTimestamp: time.Now().Unix(),
 	}
 }
+
+// GetInstanceTypeRules returns the instance type rules
+// set by the user or the default ones when not set.
+func (c *Config) GetInstanceTypeRules() []InstanceTypeRule {
+	if c.InstanceTypeRules == nil {
+		return DefaultInstanceTypeRules
+	}
+
+	return c.InstanceTypeRules
+}
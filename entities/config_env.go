@@ -1,6 +1,9 @@
 package entities
 
-import "errors"
+import (
+	"errors"
+	"sort"
+)
 
 func (c *Config) SetEnv(clusterName string, env *Env) error {
 	if env == nil {
@@ -57,3 +60,62 @@ func (c *Config) CountEnvsInCluster(clusterName string) (int, error) {
 
 	return len(c.Clusters[clusterName].Envs), nil
 }
+
+// GetEnvsForRepository returns all the envs (one per variant)
+// created for the passed repository, sorted by name.
+func (c *Config) GetEnvsForRepository(
+	clusterName string,
+	repoOwner string,
+	repoName string,
+) ([]*Env, error) {
+
+	if !c.ClusterExists(clusterName) {
+		return nil, ErrClusterNotExists{
+			ClusterName: clusterName,
+		}
+	}
+
+	envs := []*Env{}
+
+	for _, env := range c.Clusters[clusterName].Envs {
+		if env.ResolvedRepository.Owner == repoOwner &&
+			env.ResolvedRepository.Name == repoName {
+
+			envs = append(envs, env)
+		}
+	}
+
+	sort.Slice(envs, func(i, j int) bool {
+		return envs[i].Name < envs[j].Name
+	})
+
+	return envs, nil
+}
+
+// CheckEnvNameSlugUniqueness ensures that the slug of the passed
+// env name is not already used by another env in the cluster
+// (eg: "yolo-sh/yolo@feature-x" and "yolo-sh/yolo-feature-x").
+func (c *Config) CheckEnvNameSlugUniqueness(
+	clusterName string,
+	envName string,
+) error {
+
+	if !c.ClusterExists(clusterName) {
+		return ErrClusterNotExists{
+			ClusterName: clusterName,
+		}
+	}
+
+	envNameSlug := BuildEnvNameSlug(envName)
+
+	for _, env := range c.Clusters[clusterName].Envs {
+		if env.Name != envName && env.GetNameSlug() == envNameSlug {
+			return ErrEnvNameSlugConflict{
+				EnvName:            envName,
+				ConflictingEnvName: env.Name,
+			}
+		}
+	}
+
+	return nil
+}
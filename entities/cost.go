@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"time"
+)
+
+// HoursPerMonth is the average number of hours in a month
+// (365 days * 24 hours / 12 months), as used by cloud providers.
+const HoursPerMonth = 730
+
+// CostEstimate represents the estimated cost of running an instance type.
+type CostEstimate struct {
+	HourlyCost float64 `json:"hourly_cost"`
+	Currency   string  `json:"currency"`
+}
+
+// PricingTable maps instance types to their estimated cost.
+// It takes precedence over the estimates returned by the
+// cloud service so that costs could be estimated offline.
+type PricingTable map[string]CostEstimate
+
+type EnvCost struct {
+	Env *Env `json:"-"`
+	// The hourly and monthly costs are zero for stopped
+	// envs given that their instance is not billed
+	Stopped     bool    `json:"stopped"`
+	HourlyCost  float64 `json:"hourly_cost"`
+	MonthlyCost float64 `json:"monthly_cost"`
+	CostSoFar   float64 `json:"cost_so_far"`
+	Currency    string  `json:"currency"`
+}
+
+func NewEnvCost(
+	env *Env,
+	estimate CostEstimate,
+	now time.Time,
+) EnvCost {
+
+	envCost := EnvCost{
+		Env:       env,
+		Stopped:   env.IsStopped(),
+		CostSoFar: estimate.HourlyCost * env.GetRunningDuration(now).Hours(),
+		Currency:  estimate.Currency,
+	}
+
+	if !envCost.Stopped {
+		envCost.HourlyCost = estimate.HourlyCost
+		envCost.MonthlyCost = estimate.HourlyCost * HoursPerMonth
+	}
+
+	return envCost
+}
+
+type ClusterCost struct {
+	Cluster     *Cluster  `json:"-"`
+	Envs        []EnvCost `json:"envs"`
+	HourlyCost  float64   `json:"hourly_cost"`
+	MonthlyCost float64   `json:"monthly_cost"`
+	CostSoFar   float64   `json:"cost_so_far"`
+	Currency    string    `json:"currency"`
+}
+
+func NewClusterCost(cluster *Cluster) *ClusterCost {
+	return &ClusterCost{
+		Cluster: cluster,
+		Envs:    []EnvCost{},
+	}
+}
+
+func (c *ClusterCost) AddEnvCost(envCost EnvCost) error {
+	if len(c.Currency) > 0 && c.Currency != envCost.Currency {
+		return ErrCostCurrencyMismatch{
+			ExpectedCurrency: c.Currency,
+			Currency:         envCost.Currency,
+		}
+	}
+
+	c.Currency = envCost.Currency
+	c.Envs = append(c.Envs, envCost)
+
+	c.HourlyCost += envCost.HourlyCost
+	c.MonthlyCost += envCost.MonthlyCost
+	c.CostSoFar += envCost.CostSoFar
+
+	return nil
+}
+
+type CostReport struct {
+	Clusters    []ClusterCost `json:"clusters"`
+	HourlyCost  float64       `json:"hourly_cost"`
+	MonthlyCost float64       `json:"monthly_cost"`
+	CostSoFar   float64       `json:"cost_so_far"`
+	Currency    string        `json:"currency"`
+}
+
+func NewCostReport() *CostReport {
+	return &CostReport{
+		Clusters: []ClusterCost{},
+	}
+}
+
+func (r *CostReport) AddClusterCost(clusterCost ClusterCost) error {
+	if len(clusterCost.Envs) == 0 {
+		r.Clusters = append(r.Clusters, clusterCost)
+		return nil
+	}
+
+	if len(r.Currency) > 0 && r.Currency != clusterCost.Currency {
+		return ErrCostCurrencyMismatch{
+			ExpectedCurrency: r.Currency,
+			Currency:         clusterCost.Currency,
+		}
+	}
+
+	r.Currency = clusterCost.Currency
+	r.Clusters = append(r.Clusters, clusterCost)
+
+	r.HourlyCost += clusterCost.HourlyCost
+	r.MonthlyCost += clusterCost.MonthlyCost
+	r.CostSoFar += clusterCost.CostSoFar
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package entities
+
+type ErrCostCurrencyMismatch struct {
+	ExpectedCurrency string
+	Currency         string
+}
+
+func (ErrCostCurrencyMismatch) Error() string {
+	return "ErrCostCurrencyMismatch"
+}
+
+type ErrCostEstimateNotFound struct {
+	InstanceType string
+}
+
+func (ErrCostEstimateNotFound) Error() string {
+	return "ErrCostEstimateNotFound"
+}
@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewEnvCost(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	env := &Env{
+		CreatedAtTimestamp: now.Add(-10 * time.Hour).Unix(),
+	}
+
+	envCost := NewEnvCost(env, CostEstimate{
+		HourlyCost: 0.5,
+		Currency:   "USD",
+	}, now)
+
+	if envCost.MonthlyCost != 0.5*HoursPerMonth {
+		t.Fatalf("expected monthly cost to equal '%f', got '%f'", 0.5*HoursPerMonth, envCost.MonthlyCost)
+	}
+
+	if envCost.CostSoFar != 5 {
+		t.Fatalf("expected cost so far to equal '5', got '%f'", envCost.CostSoFar)
+	}
+}
+
+func TestNewEnvCostWithStoppedEnv(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	// Ran 2 hours, stopped 3 hours, ran 1 hour and stopped since 4 hours
+	env := &Env{
+		Status:             EnvStatusStopped,
+		CreatedAtTimestamp: now.Add(-10 * time.Hour).Unix(),
+		StoppedAtTimestamp: now.Add(-8 * time.Hour).Unix(),
+	}
+
+	env.RecordStart(now.Add(-5 * time.Hour))
+	env.Status = EnvStatusStopped
+	env.RecordStop(now.Add(-4 * time.Hour))
+
+	envCost := NewEnvCost(env, CostEstimate{
+		HourlyCost: 0.5,
+		Currency:   "USD",
+	}, now)
+
+	if !envCost.Stopped {
+		t.Fatalf("expected env cost to be stopped")
+	}
+
+	if envCost.HourlyCost != 0 || envCost.MonthlyCost != 0 {
+		t.Fatalf(
+			"expected hourly and monthly costs to equal '0', got '%f' and '%f'",
+			envCost.HourlyCost,
+			envCost.MonthlyCost,
+		)
+	}
+
+	if envCost.CostSoFar != 1.5 {
+		t.Fatalf("expected cost so far to equal '1.5', got '%f'", envCost.CostSoFar)
+	}
+}
+
+func TestAddEnvCostWithCurrencyMismatch(t *testing.T) {
+	clusterCost := NewClusterCost(&Cluster{})
+
+	err := clusterCost.AddEnvCost(EnvCost{HourlyCost: 1, Currency: "USD"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = clusterCost.AddEnvCost(EnvCost{HourlyCost: 1, Currency: "EUR"})
+
+	if !errors.As(err, &ErrCostCurrencyMismatch{}) {
+		t.Fatalf("expected currency mismatch error, got '%+v'", err)
+	}
+
+	if clusterCost.HourlyCost != 1 {
+		t.Fatalf("expected hourly cost to equal '1', got '%f'", clusterCost.HourlyCost)
+	}
+}
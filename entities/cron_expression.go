@@ -0,0 +1,232 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit limits the search of the next and previous
+// activation times for the expressions that never match (eg: "0 0 30 2 *").
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+type cronField struct {
+	min int
+	max int
+}
+
+var (
+	cronMinuteField     = cronField{min: 0, max: 59}
+	cronHourField       = cronField{min: 0, max: 23}
+	cronDayOfMonthField = cronField{min: 1, max: 31}
+	cronMonthField      = cronField{min: 1, max: 12}
+	cronDayOfWeekField  = cronField{min: 0, max: 7} // 0 and 7 are Sunday
+)
+
+// CronExpression represents a standard five-field cron expression
+// (minute, hour, day of month, month and day of week).
+//
+// Each field supports wildcards ("*"), values ("5"),
+// ranges ("1-5"), steps ("*/15", "0-30/10") and lists ("1,15").
+type CronExpression struct {
+	expression string
+
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+
+	restrictedDaysOfMonth bool
+	restrictedDaysOfWeek  bool
+}
+
+func ParseCronExpression(expression string) (*CronExpression, error) {
+	errInvalidExpression := ErrInvalidCronExpression{
+		Expression: expression,
+	}
+
+	fields := strings.Fields(expression)
+
+	if len(fields) != 5 {
+		return nil, errInvalidExpression
+	}
+
+	parsedFields := make([]map[int]bool, 5)
+	cronFields := []cronField{
+		cronMinuteField,
+		cronHourField,
+		cronDayOfMonthField,
+		cronMonthField,
+		cronDayOfWeekField,
+	}
+
+	for fieldIndex, field := range fields {
+		parsedField, err := parseCronField(field, cronFields[fieldIndex])
+
+		if err != nil {
+			return nil, errInvalidExpression
+		}
+
+		parsedFields[fieldIndex] = parsedField
+	}
+
+	daysOfWeek := parsedFields[4]
+
+	if daysOfWeek[7] {
+		daysOfWeek[0] = true
+	}
+
+	return &CronExpression{
+		expression: expression,
+
+		minutes:     parsedFields[0],
+		hours:       parsedFields[1],
+		daysOfMonth: parsedFields[2],
+		months:      parsedFields[3],
+		daysOfWeek:  daysOfWeek,
+
+		restrictedDaysOfMonth: !strings.HasPrefix(fields[2], "*"),
+		restrictedDaysOfWeek:  !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (map[int]bool, error) {
+	values := map[int]bool{}
+
+	for _, fieldPart := range strings.Split(field, ",") {
+		rangePart := fieldPart
+		step := 1
+
+		if stepIndex := strings.Index(fieldPart, "/"); stepIndex != -1 {
+			parsedStep, err := strconv.Atoi(fieldPart[stepIndex+1:])
+
+			if err != nil || parsedStep < 1 {
+				return nil, ErrInvalidCronExpression{}
+			}
+
+			rangePart = fieldPart[:stepIndex]
+			step = parsedStep
+		}
+
+		start, end := bounds.min, bounds.max
+
+		if rangePart != "*" {
+			rangeBounds := strings.SplitN(rangePart, "-", 2)
+			parsedStart, err := strconv.Atoi(rangeBounds[0])
+
+			if err != nil {
+				return nil, ErrInvalidCronExpression{}
+			}
+
+			start, end = parsedStart, parsedStart
+
+			if len(rangeBounds) == 2 {
+				parsedEnd, err := strconv.Atoi(rangeBounds[1])
+
+				if err != nil {
+					return nil, ErrInvalidCronExpression{}
+				}
+
+				end = parsedEnd
+			} else if step > 1 { // eg: "5/10" means "5-max/10"
+				end = bounds.max
+			}
+		}
+
+		if start < bounds.min || end > bounds.max || start > end {
+			return nil, ErrInvalidCronExpression{}
+		}
+
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+func (c *CronExpression) String() string {
+	return c.expression
+}
+
+func (c *CronExpression) matchesDay(t time.Time) bool {
+	dayOfMonthMatches := c.daysOfMonth[t.Day()]
+	dayOfWeekMatches := c.daysOfWeek[int(t.Weekday())]
+
+	// Like in the standard cron, when both the day of month
+	// and the day of week are restricted, one match is enough
+	if c.restrictedDaysOfMonth && c.restrictedDaysOfWeek {
+		return dayOfMonthMatches || dayOfWeekMatches
+	}
+
+	return dayOfMonthMatches && dayOfWeekMatches
+}
+
+// Next returns the first activation time strictly after the passed time.
+// The returned time uses the location of the passed time.
+func (c *CronExpression) Next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		var next time.Time
+
+		switch {
+		case !c.months[int(t.Month())]:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchesDay(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !c.hours[t.Hour()]:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !c.minutes[t.Minute()]:
+			next = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+
+		// Daylight saving time transitions
+		// must not make the search go backwards
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+
+		t = next
+	}
+
+	return time.Time{}, false
+}
+
+// Previous returns the last activation time before or at the passed time.
+// The returned time uses the location of the passed time.
+func (c *CronExpression) Previous(before time.Time) (time.Time, bool) {
+	loc := before.Location()
+	t := before.Truncate(time.Minute)
+	limit := before.Add(-cronSearchLimit)
+
+	for t.After(limit) {
+		var previous time.Time
+
+		switch {
+		case !c.months[int(t.Month())]:
+			previous = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute)
+		case !c.matchesDay(t):
+			previous = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+		case !c.hours[t.Hour()]:
+			previous = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+		case !c.minutes[t.Minute()]:
+			previous = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+
+		if !previous.Before(t) {
+			previous = t.Add(-time.Minute)
+		}
+
+		t = previous
+	}
+
+	return time.Time{}, false
+}
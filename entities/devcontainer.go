@@ -0,0 +1,323 @@
+package entities
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DevContainerFilePaths lists the paths where the devcontainer
+// configuration may be found, by order of precedence.
+var DevContainerFilePaths = []string{
+	".devcontainer/devcontainer.json",
+	".devcontainer.json",
+}
+
+// DevContainer represents the subset of the "devcontainer.json"
+// specification used to define envs (see https://containers.dev).
+type DevContainer struct {
+	ForwardPorts []string `json:"forward_ports"`
+	// Ports forwarded from other containers (eg: "db:5432")
+	// are not opened given that envs don't run them
+	UnsupportedForwardPorts []string `json:"unsupported_forward_ports"`
+	// Commands run in the env once created
+	PostCreateCommands []string                   `json:"post_create_commands"`
+	Features           map[string]json.RawMessage `json:"features"`
+	ContainerEnv       map[string]string          `json:"container_env"`
+	HostRequirements   HostRequirements           `json:"host_requirements"`
+}
+
+type devContainerJSON struct {
+	ForwardPorts      []interface{}              `json:"forwardPorts"`
+	PostCreateCommand interface{}                `json:"postCreateCommand"`
+	Features          map[string]json.RawMessage `json:"features"`
+	ContainerEnv      map[string]string          `json:"containerEnv"`
+	HostRequirements  struct {
+		CPUs    int         `json:"cpus"`
+		Memory  string      `json:"memory"`
+		Storage string      `json:"storage"`
+		GPU     interface{} `json:"gpu"`
+	} `json:"hostRequirements"`
+}
+
+// ParseDevContainer parses the passed "devcontainer.json" content.
+// Like VS Code, comments and trailing commas are supported.
+func ParseDevContainer(devContainerContent string) (*DevContainer, error) {
+	var parsedJSON devContainerJSON
+
+	err := json.Unmarshal(
+		[]byte(sanitizeJSONC(devContainerContent)),
+		&parsedJSON,
+	)
+
+	if err != nil {
+		return nil, ErrInvalidDevContainer{
+			Reason: err.Error(),
+		}
+	}
+
+	forwardPorts, unsupportedForwardPorts, err := parseDevContainerForwardPorts(
+		parsedJSON.ForwardPorts,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	postCreateCommands, err := parseDevContainerCommand(parsedJSON.PostCreateCommand)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hostRequirements := HostRequirements{
+		CPUs: parsedJSON.HostRequirements.CPUs,
+	}
+
+	sizes := []struct {
+		name  string
+		value string
+		dest  *int64
+	}{
+		{name: "memory", value: parsedJSON.HostRequirements.Memory, dest: &hostRequirements.MemoryBytes},
+		{name: "storage", value: parsedJSON.HostRequirements.Storage, dest: &hostRequirements.StorageBytes},
+	}
+
+	for _, size := range sizes {
+		if len(size.value) == 0 {
+			continue
+		}
+
+		parsedSize, ok := ParseByteSize(size.value)
+
+		if !ok {
+			return nil, ErrInvalidDevContainer{
+				Reason: "invalid " + size.name + " requirement \"" + size.value + "\"",
+			}
+		}
+
+		*size.dest = parsedSize
+	}
+
+	// "gpu" may be a boolean, "optional" or an object
+	// with the GPU requirements (eg: {"cores": 1000})
+	switch gpu := parsedJSON.HostRequirements.GPU.(type) {
+	case bool:
+		hostRequirements.GPU = gpu
+	case map[string]interface{}:
+		hostRequirements.GPU = true
+	}
+
+	containerEnv := parsedJSON.ContainerEnv
+
+	if containerEnv == nil {
+		containerEnv = map[string]string{}
+	}
+
+	features := parsedJSON.Features
+
+	if features == nil {
+		features = map[string]json.RawMessage{}
+	}
+
+	return &DevContainer{
+		ForwardPorts:            forwardPorts,
+		UnsupportedForwardPorts: unsupportedForwardPorts,
+		PostCreateCommands:      postCreateCommands,
+		Features:                features,
+		ContainerEnv:            containerEnv,
+		HostRequirements:        hostRequirements,
+	}, nil
+}
+
+// parseDevContainerForwardPorts handles ports passed as
+// numbers (eg: 3000) or as strings (eg: "3000" or "db:5432").
+// Ports passed as "host:port" are returned separately given
+// that they target other containers than the env one.
+func parseDevContainerForwardPorts(
+	forwardPorts []interface{},
+) (ports []string, unsupportedPorts []string, err error) {
+
+	ports = []string{}
+	unsupportedPorts = []string{}
+
+	for _, forwardPort := range forwardPorts {
+		switch port := forwardPort.(type) {
+		case float64:
+			ports = append(ports, strconv.Itoa(int(port)))
+		case string:
+			if strings.Contains(port, ":") {
+				unsupportedPorts = append(unsupportedPorts, port)
+				continue
+			}
+
+			ports = append(ports, port)
+		default:
+			return nil, nil, ErrInvalidDevContainer{
+				Reason: "invalid forwarded port",
+			}
+		}
+	}
+
+	return ports, unsupportedPorts, nil
+}
+
+// parseDevContainerCommand handles commands passed as strings,
+// as arrays of arguments or as objects of parallel commands.
+func parseDevContainerCommand(command interface{}) ([]string, error) {
+	errInvalidCommand := ErrInvalidDevContainer{
+		Reason: "invalid command",
+	}
+
+	switch typedCommand := command.(type) {
+	case nil:
+		return []string{}, nil
+	case string:
+		return []string{typedCommand}, nil
+	case []interface{}:
+		args := []string{}
+
+		for _, arg := range typedCommand {
+			argAsString, ok := arg.(string)
+
+			if !ok {
+				return nil, errInvalidCommand
+			}
+
+			args = append(args, quoteShellArg(argAsString))
+		}
+
+		return []string{strings.Join(args, " ")}, nil
+	case map[string]interface{}:
+		commandNames := []string{}
+
+		for commandName := range typedCommand {
+			commandNames = append(commandNames, commandName)
+		}
+
+		// Sorted to get a deterministic order
+		sort.Strings(commandNames)
+
+		commands := []string{}
+
+		for _, commandName := range commandNames {
+			parsedCommands, err := parseDevContainerCommand(typedCommand[commandName])
+
+			if err != nil {
+				return nil, err
+			}
+
+			commands = append(commands, parsedCommands...)
+		}
+
+		return commands, nil
+	}
+
+	return nil, errInvalidCommand
+}
+
+func quoteShellArg(arg string) string {
+	if len(arg) > 0 && !strings.ContainsAny(arg, " \t\n'\"\\$`;&|<>()*?[]#~{}") {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// sanitizeJSONC removes the comments and
+// the trailing commas from the passed JSON content.
+func sanitizeJSONC(content string) string {
+	var sanitized strings.Builder
+
+	inString := false
+
+	for i := 0; i < len(content); i++ {
+		char := content[i]
+
+		if inString {
+			sanitized.WriteByte(char)
+
+			if char == '\\' && i+1 < len(content) {
+				i++
+				sanitized.WriteByte(content[i])
+			} else if char == '"' {
+				inString = false
+			}
+
+			continue
+		}
+
+		switch {
+		case char == '"':
+			inString = true
+			sanitized.WriteByte(char)
+		case strings.HasPrefix(content[i:], "//"):
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+
+			if i < len(content) {
+				sanitized.WriteByte('\n')
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			commentEnd := strings.Index(content[i+2:], "*/")
+
+			if commentEnd == -1 {
+				i = len(content)
+			} else {
+				i += 2 + commentEnd + 1
+			}
+		case char == ',':
+			nextChar := nextNonSpaceJSONCChar(content[i+1:])
+
+			if nextChar != '}' && nextChar != ']' {
+				sanitized.WriteByte(char)
+			}
+		default:
+			sanitized.WriteByte(char)
+		}
+	}
+
+	return sanitized.String()
+}
+
+// nextNonSpaceJSONCChar returns the next char
+// that is neither a space nor part of a comment.
+func nextNonSpaceJSONCChar(content string) byte {
+	for i := 0; i < len(content); i++ {
+		switch {
+		case strings.ContainsRune(" \t\r\n", rune(content[i])):
+			continue
+		case strings.HasPrefix(content[i:], "//"):
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			commentEnd := strings.Index(content[i+2:], "*/")
+
+			if commentEnd == -1 {
+				return 0
+			}
+
+			i += 2 + commentEnd + 1
+		default:
+			return content[i]
+		}
+	}
+
+	return 0
+}
+
+// Validate ensures that the forwarded ports could be opened.
+func (d *DevContainer) Validate(reservedPorts []string) error {
+	for _, port := range d.ForwardPorts {
+		err := CheckPortValidity(port, reservedPorts)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package entities
+
+type ErrInvalidDevContainer struct {
+	Reason string
+}
+
+func (ErrInvalidDevContainer) Error() string {
+	return "ErrInvalidDevContainer"
+}
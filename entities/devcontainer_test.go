@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseDevContainer(t *testing.T) {
+	devContainerContent := `{
+	// Comments are supported
+	"name": "Yolo",
+	"forwardPorts": [3000, "8080", "db:5432",],
+	"postCreateCommand": {
+		"server": "npm install",
+		"client": ["yarn", "run", "build app"]
+	},
+	/* Features are
+	kept as is */
+	"features": {
+		"ghcr.io/devcontainers/features/go:1": {"version": "1.18"},
+	},
+	"containerEnv": {
+		"NODE_ENV": "development // not a comment"
+	},
+	"hostRequirements": {
+		"cpus": 4,
+		"memory": "8gb",
+		"storage": "32GB",
+		"gpu": "optional"
+	}
+}`
+
+	devContainer, err := ParseDevContainer(devContainerContent)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedDevContainer := &DevContainer{
+		ForwardPorts:            []string{"3000", "8080"},
+		UnsupportedForwardPorts: []string{"db:5432"},
+		PostCreateCommands: []string{
+			"yarn run 'build app'",
+			"npm install",
+		},
+		Features: map[string]json.RawMessage{
+			"ghcr.io/devcontainers/features/go:1": json.RawMessage(`{"version": "1.18"}`),
+		},
+		ContainerEnv: map[string]string{
+			"NODE_ENV": "development // not a comment",
+		},
+		HostRequirements: HostRequirements{
+			CPUs:         4,
+			MemoryBytes:  8 * 1024 * 1024 * 1024,
+			StorageBytes: 32 * 1024 * 1024 * 1024,
+			GPU:          false,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedDevContainer, devContainer) {
+		t.Fatalf(
+			"expected devcontainer to equal '%+v', got '%+v'",
+			expectedDevContainer,
+			devContainer,
+		)
+	}
+}
+
+func TestParseInvalidDevContainer(t *testing.T) {
+	testCases := []struct {
+		test                string
+		devContainerContent string
+	}{
+		{
+			test:                "with invalid JSON",
+			devContainerContent: `{"forwardPorts": [3000}`,
+		},
+
+		{
+			test:                "with invalid memory requirement",
+			devContainerContent: `{"hostRequirements": {"memory": "lots"}}`,
+		},
+
+		{
+			test:                "with invalid forwarded port",
+			devContainerContent: `{"forwardPorts": [true]}`,
+		},
+
+		{
+			test:                "with invalid command",
+			devContainerContent: `{"postCreateCommand": 42}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseDevContainer(tc.devContainerContent)
+
+			if _, ok := err.(ErrInvalidDevContainer); !ok {
+				t.Fatalf("expected invalid devcontainer error, got '%+v'", err)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package entities
 
 import (
 	"encoding/json"
-	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -11,32 +10,57 @@ import (
 	"github.com/gosimple/slug"
 )
 
-type EnvSSHHostKey struct {
-	Algorithm   string `json:"algorithm"`
-	Fingerprint string `json:"fingerprint"`
-}
-
 type EnvStatus string
 
 const (
 	EnvStatusCreating EnvStatus = "creating"
 	EnvStatusCreated  EnvStatus = "created"
 	EnvStatusRemoving EnvStatus = "removing"
+	EnvStatusStopping EnvStatus = "stopping"
+	EnvStatusStopped  EnvStatus = "stopped"
+	EnvStatusStarting EnvStatus = "starting"
+)
+
+// EnvStopReason represents what stopped an env.
+// Empty means that the env was stopped
+// before the reasons were recorded.
+type EnvStopReason string
+
+const (
+	EnvStopReasonUser     EnvStopReason = "user"
+	EnvStopReasonExpired  EnvStopReason = "expired"
+	EnvStopReasonIdle     EnvStopReason = "idle"
+	EnvStopReasonSchedule EnvStopReason = "schedule"
 )
 
 type Env struct {
-	ID                       string                `json:"id"`
-	Name                     string                `json:"name"`
-	InfrastructureJSON       string                `json:"infrastructure_json"`
-	InstanceType             string                `json:"instance_type"`
-	InstancePublicIPAddress  string                `json:"instance_public_ip_address"`
-	SSHHostKeys              []EnvSSHHostKey       `json:"ssh_host_keys"`
-	SSHKeyPairPEMContent     string                `json:"ssh_key_pair_pem_content"`
-	ResolvedRepository       ResolvedEnvRepository `json:"resolved_repository"`
-	OpenedPorts              map[string]bool       `json:"opened_ports"`
-	Status                   EnvStatus             `json:"status"`
-	AdditionalPropertiesJSON string                `json:"additional_properties_json"`
-	CreatedAtTimestamp       int64                 `json:"created_at_timestamp"`
+	ID                      string                 `json:"id"`
+	Name                    string                 `json:"name"`
+	InfrastructureJSON      string                 `json:"infrastructure_json"`
+	InstanceType            string                 `json:"instance_type"`
+	InstancePublicIPAddress string                 `json:"instance_public_ip_address"`
+	SSHHostKeys             []EnvSSHHostKey        `json:"ssh_host_keys"`
+	SSHKeyPairPEMContent    string                 `json:"ssh_key_pair_pem_content"`
+	SSHKeyPairAlgorithm     EnvSSHKeyPairAlgorithm `json:"ssh_key_pair_algorithm"`
+	SSHKeyPairBits          int                    `json:"ssh_key_pair_bits"`
+	SSHPublicKeyContent     string                 `json:"ssh_public_key_content"`
+	ResolvedRepository      ResolvedEnvRepository  `json:"resolved_repository"`
+	// Indexed by port spec (see "PortSpec.String")
+	OpenedPorts map[string]EnvOpenedPort `json:"opened_port_specs"`
+	Status      EnvStatus                `json:"status"`
+	// Set only when the env is stopped
+	StopReason EnvStopReason `json:"stop_reason"`
+	// Zero when the env is running or stopped before the stop times were
+	// recorded. Used to exclude the stopped periods from the costs.
+	StoppedAtTimestamp       int64                   `json:"stopped_at_timestamp"`
+	StoppedDurationSeconds   int64                   `json:"stopped_duration_seconds"`
+	AdditionalPropertiesJSON string                  `json:"additional_properties_json"`
+	CreatedAtTimestamp       int64                   `json:"created_at_timestamp"`
+	ExpiresAtTimestamp       int64                   `json:"expires_at_timestamp"`
+	IdlePolicy               *IdlePolicy             `json:"idle_policy"`
+	Schedule                 *Schedule               `json:"schedule"`
+	SSHKeyStrategy           EnvSSHKeyStrategy       `json:"ssh_key_strategy"`
+	RepositoryAccessMode     EnvRepositoryAccessMode `json:"repository_access_mode"`
 }
 
 func NewEnv(
@@ -51,18 +75,132 @@ func NewEnv(
 		InstanceType:       instanceType,
 		SSHHostKeys:        []EnvSSHHostKey{},
 		ResolvedRepository: resolvedRepository,
-		OpenedPorts:        map[string]bool{},
+		OpenedPorts:        map[string]EnvOpenedPort{},
 		Status:             EnvStatusCreating,
 		CreatedAtTimestamp: time.Now().Unix(),
 	}
 }
 
+// UnmarshalJSON migrates the envs stored before the port specs were
+// introduced. In these envs, the opened ports are stored in the
+// "opened_ports" map as single TCP ports opened to the world.
+func (e *Env) UnmarshalJSON(data []byte) error {
+	// Prevents infinite recursion
+	type env Env
+
+	var decodedEnv struct {
+		env
+		LegacyOpenedPorts map[string]bool `json:"opened_ports"`
+	}
+
+	if err := json.Unmarshal(data, &decodedEnv); err != nil {
+		return err
+	}
+
+	*e = Env(decodedEnv.env)
+
+	if e.OpenedPorts == nil {
+		e.OpenedPorts = map[string]EnvOpenedPort{}
+	}
+
+	for port, opened := range decodedEnv.LegacyOpenedPorts {
+		portAsInt, err := strconv.Atoi(port)
+
+		if !opened || err != nil {
+			continue
+		}
+
+		e.SetPortAsOpened(NewTCPPortSpec(portAsInt))
+	}
+
+	return nil
+}
+
 func (e *Env) GetNameSlug() string {
 	return BuildEnvNameSlug(e.Name)
 }
 
-func (e *Env) GetSSHKeyPairName() string {
-	return "yolo-" + e.GetNameSlug() + "-key-pair"
+// SetTTL makes the env expire after the passed duration.
+// A zero duration means that the env never expires.
+func (e *Env) SetTTL(ttl time.Duration, now time.Time) {
+	if ttl <= 0 {
+		e.ExpiresAtTimestamp = 0
+		return
+	}
+
+	e.ExpiresAtTimestamp = now.Add(ttl).Unix()
+}
+
+// ExtendTTL postpones the expiration of the env by the passed duration.
+// Already expired envs are extended from now.
+//
+// The envs that never expire could not be extended
+// (see "SetTTL" to make them expire).
+func (e *Env) ExtendTTL(extension time.Duration, now time.Time) error {
+	if extension <= 0 {
+		return ErrInvalidEnvTTLExtension{
+			Extension: extension,
+		}
+	}
+
+	if e.ExpiresAtTimestamp == 0 {
+		return ErrExtendEnvWithoutTTL{
+			EnvName: e.Name,
+		}
+	}
+
+	expiresAt := now
+
+	if e.ExpiresAtTimestamp > 0 && e.ExpiresAtTimestamp > now.Unix() {
+		expiresAt = time.Unix(e.ExpiresAtTimestamp, 0)
+	}
+
+	e.ExpiresAtTimestamp = expiresAt.Add(extension).Unix()
+
+	return nil
+}
+
+func (e *Env) HasExpired(now time.Time) bool {
+	return e.ExpiresAtTimestamp > 0 && e.ExpiresAtTimestamp <= now.Unix()
+}
+
+// IsStopped returns whether the instance of the env is not running.
+func (e *Env) IsStopped() bool {
+	return e.Status == EnvStatusStopping || e.Status == EnvStatusStopped
+}
+
+// RecordStop is called once the env is stopped.
+func (e *Env) RecordStop(now time.Time) {
+	e.StoppedAtTimestamp = now.Unix()
+}
+
+// RecordStart is called when the env is started. The
+// stopped period is excluded from the running duration.
+func (e *Env) RecordStart(now time.Time) {
+	if e.StoppedAtTimestamp > 0 && now.Unix() > e.StoppedAtTimestamp {
+		e.StoppedDurationSeconds += now.Unix() - e.StoppedAtTimestamp
+	}
+
+	e.StoppedAtTimestamp = 0
+}
+
+// GetRunningDuration returns the time during which the env
+// instance was running since the env creation.
+func (e *Env) GetRunningDuration(now time.Time) time.Duration {
+	end := now
+
+	if e.IsStopped() && e.StoppedAtTimestamp > 0 {
+		end = time.Unix(e.StoppedAtTimestamp, 0)
+	}
+
+	runningDuration := end.Sub(time.Unix(e.CreatedAtTimestamp, 0)) -
+		time.Duration(e.StoppedDurationSeconds)*time.Second
+
+	if runningDuration < 0 {
+		return 0
+	}
+
+	return runningDuration
 }
 
 func (e *Env) SetInfrastructureJSON(infrastructure interface{}) error {
@@ -90,38 +228,37 @@ func (e *Env) SetAdditionalPropertiesJSON(additionalProperties interface{}) erro
 }
 
 func BuildEnvNameSlug(name string) string {
-	return slug.Make(name)
+	nameParts := strings.SplitN(name, EnvRepositoryVariantSeparator, 2)
+
+	if len(nameParts) == 1 {
+		return slug.Make(name)
+	}
+
+	// The variant is slugified separately given that
+	// the separator would be transliterated (eg: "@" => "at")
+	return slug.Make(nameParts[0]) + "-" + slug.Make(nameParts[1])
 }
 
+// BuildEnvNameFromResolvedRepo returns the env name of the passed
+// repository (eg: "yolo-sh/yolo" or "gitlab.com/yolo-sh/yolo@feature-x").
+//
+// The host is part of the name, unless it is the default one, so that
+// the same repository path on different hosts maps to different envs.
 func BuildEnvNameFromResolvedRepo(
 	resolvedRepo ResolvedEnvRepository,
 ) string {
 
-	return resolvedRepo.Owner + "/" + resolvedRepo.Name
-}
-
-func ParseSSHHostKeys(hostKeysContent string) ([]EnvSSHHostKey, error) {
-	sanitizedHostKeysContent := strings.TrimSpace(hostKeysContent)
-	hostKeys := strings.Split(sanitizedHostKeysContent, "\n")
+	envName := resolvedRepo.Owner + "/" + resolvedRepo.Name
 
-	parsedHostKeys := []EnvSSHHostKey{}
-
-	for _, hostKey := range hostKeys {
-		sanitizedHostKey := strings.TrimSpace(hostKey)
-		hostKeyComponents := strings.Split(sanitizedHostKey, " ")
-
-		// eg: (ssh-rsa) (AAAAB3NzaC1yc===) (root@ip-10-0-0-200)
-		if len(hostKeyComponents) != 3 {
-			return nil, fmt.Errorf("invalid host key (\"%s\")", hostKey)
-		}
+	if len(resolvedRepo.Host) > 0 && resolvedRepo.Host != DefaultEnvRepositoryHost {
+		envName = resolvedRepo.Host + "/" + envName
+	}
 
-		parsedHostKeys = append(parsedHostKeys, EnvSSHHostKey{
-			Algorithm:   hostKeyComponents[0],
-			Fingerprint: hostKeyComponents[1],
-		})
+	if len(resolvedRepo.Variant) > 0 {
+		envName += EnvRepositoryVariantSeparator + resolvedRepo.Variant
 	}
 
-	return parsedHostKeys, nil
+	return envName
 }
 
 func CheckPortValidity(port string, reservedPorts []string) error {
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+)
+
+// EnvObservedState represents the state of an env
+// as observed by a cloud provider (eg: after someone
+// has edited the security group by hand in the console).
+type EnvObservedState struct {
+	InstanceType            string          `json:"instance_type"`
+	InstancePublicIPAddress string          `json:"instance_public_ip_address"`
+	SSHHostKeys             []EnvSSHHostKey `json:"ssh_host_keys"`
+	// Indexed by port spec (see "PortSpec.String")
+	OpenedPorts map[string]PortSpec `json:"opened_port_specs"`
+}
+
+type EnvDriftKind string
+
+const (
+	EnvDriftKindInstanceType            EnvDriftKind = "instance_type"
+	EnvDriftKindInstancePublicIPAddress EnvDriftKind = "instance_public_ip_address"
+	EnvDriftKindSSHHostKeys             EnvDriftKind = "ssh_host_keys"
+	EnvDriftKindOpenedPort              EnvDriftKind = "opened_port"
+)
+
+// EnvDriftItem represents a difference between the
+// stored env and its observed state.
+//
+// For the "opened_port" kind, "Stored" and "Observed"
+// contain the port spec when it is opened and are
+// empty when it is closed (see "PortSpec.String").
+type EnvDriftItem struct {
+	Kind     EnvDriftKind `json:"kind"`
+	Stored   string       `json:"stored"`
+	Observed string       `json:"observed"`
+}
+
+type EnvDrift struct {
+	Items []EnvDriftItem `json:"items"`
+}
+
+func (d EnvDrift) HasDrifted() bool {
+	return len(d.Items) > 0
+}
+
+func (d EnvDrift) FilterByKind(kind EnvDriftKind) []EnvDriftItem {
+	items := []EnvDriftItem{}
+
+	for _, item := range d.Items {
+		if item.Kind == kind {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func BuildEnvDrift(env *Env, observedState *EnvObservedState) EnvDrift {
+	drift := EnvDrift{
+		Items: []EnvDriftItem{},
+	}
+
+	if env.InstanceType != observedState.InstanceType {
+		drift.Items = append(drift.Items, EnvDriftItem{
+			Kind:     EnvDriftKindInstanceType,
+			Stored:   env.InstanceType,
+			Observed: observedState.InstanceType,
+		})
+	}
+
+	if env.InstancePublicIPAddress != observedState.InstancePublicIPAddress {
+		drift.Items = append(drift.Items, EnvDriftItem{
+			Kind:     EnvDriftKindInstancePublicIPAddress,
+			Stored:   env.InstancePublicIPAddress,
+			Observed: observedState.InstancePublicIPAddress,
+		})
+	}
+
+	storedHostKeys := serializeSSHHostKeys(env.SSHHostKeys)
+	observedHostKeys := serializeSSHHostKeys(observedState.SSHHostKeys)
+
+	if storedHostKeys != observedHostKeys {
+		drift.Items = append(drift.Items, EnvDriftItem{
+			Kind:     EnvDriftKindSSHHostKeys,
+			Stored:   storedHostKeys,
+			Observed: observedHostKeys,
+		})
+	}
+
+	ports := map[string]bool{}
+
+	for port := range env.OpenedPorts {
+		ports[port] = true
+	}
+
+	for port := range observedState.OpenedPorts {
+		ports[port] = true
+	}
+
+	sortedPorts := []string{}
+
+	for port := range ports {
+		sortedPorts = append(sortedPorts, port)
+	}
+
+	sort.Strings(sortedPorts)
+
+	for _, port := range sortedPorts {
+		_, storedAsOpened := env.OpenedPorts[port]
+		_, observedAsOpened := observedState.OpenedPorts[port]
+
+		if storedAsOpened == observedAsOpened {
+			continue
+		}
+
+		item := EnvDriftItem{
+			Kind: EnvDriftKindOpenedPort,
+		}
+
+		if storedAsOpened {
+			item.Stored = port
+		}
+
+		if observedAsOpened {
+			item.Observed = port
+		}
+
+		drift.Items = append(drift.Items, item)
+	}
+
+	return drift
+}
+
+func serializeSSHHostKeys(hostKeys []EnvSSHHostKey) string {
+	serializedHostKeys := []string{}
+
+	for _, hostKey := range hostKeys {
+		serializedHostKeys = append(
+			serializedHostKeys,
+			hostKey.Algorithm+" "+hostKey.Fingerprint,
+		)
+	}
+
+	sort.Strings(serializedHostKeys)
+
+	return strings.Join(serializedHostKeys, "\n")
+}
+
+// AdoptObservedState replaces the stored
+// state of the env with the observed one.
+func (e *Env) AdoptObservedState(observedState *EnvObservedState) {
+	e.InstanceType = observedState.InstanceType
+	e.InstancePublicIPAddress = observedState.InstancePublicIPAddress
+	e.SSHHostKeys = append([]EnvSSHHostKey{}, observedState.SSHHostKeys...)
+
+	storedOpenedPorts := e.OpenedPorts
+	e.OpenedPorts = map[string]EnvOpenedPort{}
+
+	for port, portSpec := range observedState.OpenedPorts {
+		e.SetPortAsOpened(portSpec)
+
+		// The metadata of the ports opened in both states are kept
+		if storedOpenedPort, portStored := storedOpenedPorts[port]; portStored {
+			e.SetOpenedPortMetadata(portSpec, storedOpenedPort.EnvOpenedPortMetadata)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildEnvDriftWithoutDrift(t *testing.T) {
+	env := &Env{
+		InstanceType:            "t2.medium",
+		InstancePublicIPAddress: "1.2.3.4",
+		SSHHostKeys: []EnvSSHHostKey{
+			{Algorithm: "ssh-ed25519", Fingerprint: "AAAAC3NzaC1lZD"},
+			{Algorithm: "ssh-rsa", Fingerprint: "AAAAB3NzaC"},
+		},
+		OpenedPorts: map[string]EnvOpenedPort{
+			"8080": {PortSpec: NewTCPPortSpec(8080)},
+		},
+	}
+
+	observedState := &EnvObservedState{
+		InstanceType:            "t2.medium",
+		InstancePublicIPAddress: "1.2.3.4",
+		SSHHostKeys: []EnvSSHHostKey{
+			{Algorithm: "ssh-rsa", Fingerprint: "AAAAB3NzaC"},
+			{Algorithm: "ssh-ed25519", Fingerprint: "AAAAC3NzaC1lZD"},
+		},
+		OpenedPorts: map[string]PortSpec{"8080": NewTCPPortSpec(8080)},
+	}
+
+	drift := BuildEnvDrift(env, observedState)
+
+	if drift.HasDrifted() {
+		t.Fatalf("expected no drift, got '%+v'", drift)
+	}
+}
+
+func TestBuildEnvDriftWithDrift(t *testing.T) {
+	env := &Env{
+		InstanceType:            "t2.medium",
+		InstancePublicIPAddress: "1.2.3.4",
+		SSHHostKeys:             []EnvSSHHostKey{},
+		OpenedPorts: map[string]EnvOpenedPort{
+			"8080": {PortSpec: NewTCPPortSpec(8080)},
+		},
+	}
+
+	observedState := &EnvObservedState{
+		InstanceType:            "t2.large",
+		InstancePublicIPAddress: "1.2.3.4",
+		SSHHostKeys:             []EnvSSHHostKey{},
+		OpenedPorts:             map[string]PortSpec{"3000": NewTCPPortSpec(3000)},
+	}
+
+	expectedDriftItems := []EnvDriftItem{
+		{
+			Kind:     EnvDriftKindInstanceType,
+			Stored:   "t2.medium",
+			Observed: "t2.large",
+		},
+
+		{
+			Kind:     EnvDriftKindOpenedPort,
+			Stored:   "",
+			Observed: "3000",
+		},
+
+		{
+			Kind:     EnvDriftKindOpenedPort,
+			Stored:   "8080",
+			Observed: "",
+		},
+	}
+
+	drift := BuildEnvDrift(env, observedState)
+
+	if !reflect.DeepEqual(expectedDriftItems, drift.Items) {
+		t.Fatalf(
+			"expected drift items to equal '%+v', got '%+v'",
+			expectedDriftItems,
+			drift.Items,
+		)
+	}
+}
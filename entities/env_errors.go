@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 type ErrEnvNotExists struct {
 	ClusterName string
 	EnvName     string
@@ -9,6 +11,15 @@ func (ErrEnvNotExists) Error() string {
 	return "ErrEnvNotExists"
 }
 
+type ErrEnvNameSlugConflict struct {
+	EnvName            string
+	ConflictingEnvName string
+}
+
+func (ErrEnvNameSlugConflict) Error() string {
+	return "ErrEnvNameSlugConflict"
+}
+
 type ErrInvalidPort struct {
 	InvalidPort string
 }
@@ -25,6 +36,45 @@ func (ErrReservedPort) Error() string {
 	return "ErrReservedPort"
 }
 
+type ErrInvalidEnvSSHKeyStrategy struct {
+	Strategy EnvSSHKeyStrategy
+}
+
+func (ErrInvalidEnvSSHKeyStrategy) Error() string {
+	return "ErrInvalidEnvSSHKeyStrategy"
+}
+
+type ErrInvalidEnvRepositoryAccessMode struct {
+	AccessMode EnvRepositoryAccessMode
+}
+
+func (ErrInvalidEnvRepositoryAccessMode) Error() string {
+	return "ErrInvalidEnvRepositoryAccessMode"
+}
+
+// ErrReadWriteDeployKeyNotSupported is returned when a read-write
+// deploy key is requested on a VCS provider that only
+// supports read-only deploy keys (eg: Bitbucket).
+type ErrReadWriteDeployKeyNotSupported struct {
+	VCSProviderHost string
+}
+
+func (ErrReadWriteDeployKeyNotSupported) Error() string {
+	return "ErrReadWriteDeployKeyNotSupported"
+}
+
+// ErrUserKeysNotSupported is returned when the user key strategy
+// is used with a VCS provider authenticated in a way that can't
+// manage user keys (eg: GitHub App installation tokens).
+// The deploy key strategy must be used instead.
+type ErrUserKeysNotSupported struct {
+	VCSProviderHost string
+}
+
+func (ErrUserKeysNotSupported) Error() string {
+	return "ErrUserKeysNotSupported"
+}
+
 type ErrInitRemovingEnv struct {
 	EnvName string
 }
@@ -49,6 +99,14 @@ func (ErrEditCreatingEnv) Error() string {
 	return "ErrEditCreatingEnv"
 }
 
+type ErrEditStoppedEnv struct {
+	EnvName string
+}
+
+func (ErrEditStoppedEnv) Error() string {
+	return "ErrEditStoppedEnv"
+}
+
 type ErrOpenPortRemovingEnv struct {
 	EnvName string
 }
@@ -80,3 +138,177 @@ type ErrClosePortCreatingEnv struct {
 func (ErrClosePortCreatingEnv) Error() string {
 	return "ErrClosePortCreatingEnv"
 }
+
+type ErrDriftRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrDriftRemovingEnv) Error() string {
+	return "ErrDriftRemovingEnv"
+}
+
+type ErrDriftCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrDriftCreatingEnv) Error() string {
+	return "ErrDriftCreatingEnv"
+}
+
+type ErrInvalidDriftResolution struct {
+	Resolution string
+}
+
+func (ErrInvalidDriftResolution) Error() string {
+	return "ErrInvalidDriftResolution"
+}
+
+type ErrExtendRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrExtendRemovingEnv) Error() string {
+	return "ErrExtendRemovingEnv"
+}
+
+type ErrExtendEnvWithoutTTL struct {
+	EnvName string
+}
+
+func (ErrExtendEnvWithoutTTL) Error() string {
+	return "ErrExtendEnvWithoutTTL"
+}
+
+type ErrInvalidEnvTTLExtension struct {
+	Extension time.Duration
+}
+
+func (ErrInvalidEnvTTLExtension) Error() string {
+	return "ErrInvalidEnvTTLExtension"
+}
+
+type ErrRotateKeysRemovingEnv struct {
+	EnvName string
+}
+
+func (ErrRotateKeysRemovingEnv) Error() string {
+	return "ErrRotateKeysRemovingEnv"
+}
+
+type ErrRotateKeysCreatingEnv struct {
+	EnvName string
+}
+
+func (ErrRotateKeysCreatingEnv) Error() string {
+	return "ErrRotateKeysCreatingEnv"
+}
+
+type ErrRotateKeysStoppedEnv struct {
+	EnvName string
+}
+
+func (ErrRotateKeysStoppedEnv) Error() string {
+	return "ErrRotateKeysStoppedEnv"
+}
+
+type ErrInvalidEnvSSHKeyPairAlgorithm struct {
+	Algorithm EnvSSHKeyPairAlgorithm
+}
+
+func (ErrInvalidEnvSSHKeyPairAlgorithm) Error() string {
+	return "ErrInvalidEnvSSHKeyPairAlgorithm"
+}
+
+type ErrInvalidEnvSSHKeyPairSize struct {
+	Algorithm EnvSSHKeyPairAlgorithm
+	Bits      int
+}
+
+func (ErrInvalidEnvSSHKeyPairSize) Error() string {
+	return "ErrInvalidEnvSSHKeyPairSize"
+}
+
+type ErrEnvMissingPublicIPAddress struct {
+	EnvName string
+}
+
+func (ErrEnvMissingPublicIPAddress) Error() string {
+	return "ErrEnvMissingPublicIPAddress"
+}
+
+type ErrInvalidEnvSSHHostKey struct {
+	EnvName   string
+	Algorithm string
+}
+
+func (ErrInvalidEnvSSHHostKey) Error() string {
+	return "ErrInvalidEnvSSHHostKey"
+}
+
+type ErrInvalidSSHConfigManagedSection struct{}
+
+func (ErrInvalidSSHConfigManagedSection) Error() string {
+	return "ErrInvalidSSHConfigManagedSection"
+}
+
+type ErrInvalidPortProtocol struct {
+	Protocol PortProtocol
+}
+
+func (ErrInvalidPortProtocol) Error() string {
+	return "ErrInvalidPortProtocol"
+}
+
+type ErrInvalidPortSourceCIDR struct {
+	SourceCIDR string
+}
+
+func (ErrInvalidPortSourceCIDR) Error() string {
+	return "ErrInvalidPortSourceCIDR"
+}
+
+// ErrCloseExpiredPorts is returned when some of the expired
+// ports could not be closed. The error of each port is
+// reported along with it.
+type ErrCloseExpiredPorts struct {
+	FailedPortsCount int
+}
+
+func (ErrCloseExpiredPorts) Error() string {
+	return "ErrCloseExpiredPorts"
+}
+
+// ErrExpireEnvs is returned when some of the expired envs
+// could not be stopped or removed. The error of each env
+// is reported along with it.
+type ErrExpireEnvs struct {
+	FailedEnvsCount int
+}
+
+func (ErrExpireEnvs) Error() string {
+	return "ErrExpireEnvs"
+}
+
+// ErrIdleStopEnvs is returned when some of the envs could not
+// be evaluated or stopped. The error of each env is reported
+// along with it.
+type ErrIdleStopEnvs struct {
+	FailedEnvsCount int
+}
+
+func (ErrIdleStopEnvs) Error() string {
+	return "ErrIdleStopEnvs"
+}
+
+// ErrEnvSSHKeyPairNotPersisted is returned when the new key pair
+// was installed in the env but could neither be saved in the
+// config nor be replaced with the old one. The new key pair is
+// returned so that the env remains accessible.
+type ErrEnvSSHKeyPairNotPersisted struct {
+	EnvName       string
+	NewSSHKeyPair EnvSSHKeyPair
+}
+
+func (ErrEnvSSHKeyPairNotPersisted) Error() string {
+	return "ErrEnvSSHKeyPairNotPersisted"
+}
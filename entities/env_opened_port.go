@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// EnvOpenedPortMetadata records why and by whom a port was opened.
+type EnvOpenedPortMetadata struct {
+	// eg: "webpack dev server"
+	Label string `json:"label"`
+	// Zero for the ports opened before the metadata were recorded
+	OpenedAtTimestamp int64 `json:"opened_at_timestamp"`
+	// Username of the VCS provider authenticated user.
+	// Empty when unknown (eg: GitHub App installation tokens)
+	OpenedBy string `json:"opened_by"`
+	// Zero means that the port never expires
+	ExpiresAtTimestamp int64 `json:"expires_at_timestamp"`
+}
+
+// NewEnvOpenedPortMetadata returns the metadata of a port opened now.
+// A zero TTL means that the port never expires.
+func NewEnvOpenedPortMetadata(
+	label string,
+	openedBy string,
+	ttl time.Duration,
+	now time.Time,
+) EnvOpenedPortMetadata {
+
+	metadata := EnvOpenedPortMetadata{
+		Label:             label,
+		OpenedAtTimestamp: now.Unix(),
+		OpenedBy:          openedBy,
+	}
+
+	if ttl > 0 {
+		metadata.ExpiresAtTimestamp = now.Add(ttl).Unix()
+	}
+
+	return metadata
+}
+
+type EnvOpenedPort struct {
+	PortSpec
+	EnvOpenedPortMetadata
+}
+
+func (e EnvOpenedPort) HasExpired(now time.Time) bool {
+	return e.ExpiresAtTimestamp > 0 && e.ExpiresAtTimestamp <= now.Unix()
+}
+
+// IsPortOpened returns whether a port with
+// the exact same spec is opened in the env.
+func (e *Env) IsPortOpened(portSpec PortSpec) bool {
+	_, portOpened := e.OpenedPorts[portSpec.String()]
+	return portOpened
+}
+
+// SetPortAsOpened is called by the cloud services once the port has been
+// opened. The metadata of the ports already opened are kept as is.
+func (e *Env) SetPortAsOpened(portSpec PortSpec) {
+	if e.OpenedPorts == nil {
+		e.OpenedPorts = map[string]EnvOpenedPort{}
+	}
+
+	openedPort := e.OpenedPorts[portSpec.String()]
+	openedPort.PortSpec = portSpec
+
+	e.OpenedPorts[portSpec.String()] = openedPort
+}
+
+// SetPortAsClosed is called by the cloud
+// services once the port has been closed.
+func (e *Env) SetPortAsClosed(portSpec PortSpec) {
+	delete(e.OpenedPorts, portSpec.String())
+}
+
+// SetOpenedPortMetadata replaces the metadata of the passed
+// port. Nothing is done when the port is not opened.
+func (e *Env) SetOpenedPortMetadata(
+	portSpec PortSpec,
+	metadata EnvOpenedPortMetadata,
+) {
+
+	openedPort, portOpened := e.OpenedPorts[portSpec.String()]
+
+	if !portOpened {
+		return
+	}
+
+	openedPort.EnvOpenedPortMetadata = metadata
+	e.OpenedPorts[portSpec.String()] = openedPort
+}
+
+// GetExpiredPorts returns the opened ports
+// that have expired, sorted by port spec.
+func (e *Env) GetExpiredPorts(now time.Time) []EnvOpenedPort {
+	expiredPorts := []EnvOpenedPort{}
+
+	for _, openedPort := range e.OpenedPorts {
+		if openedPort.HasExpired(now) {
+			expiredPorts = append(expiredPorts, openedPort)
+		}
+	}
+
+	sort.Slice(expiredPorts, func(i, j int) bool {
+		return expiredPorts[i].String() < expiredPorts[j].String()
+	})
+
+	return expiredPorts
+}
@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetOpenedPortMetadata(t *testing.T) {
+	now := time.Unix(1650000000, 0)
+	env := &Env{}
+	portSpec := NewTCPPortSpec(8080)
+
+	metadata := NewEnvOpenedPortMetadata(
+		"webpack dev server",
+		"jane",
+		time.Hour,
+		now,
+	)
+
+	// Not opened yet
+	env.SetOpenedPortMetadata(portSpec, metadata)
+
+	if env.IsPortOpened(portSpec) {
+		t.Fatalf("expected port to not be opened")
+	}
+
+	env.SetPortAsOpened(portSpec)
+	env.SetOpenedPortMetadata(portSpec, metadata)
+
+	// Opening an opened port keeps its metadata
+	env.SetPortAsOpened(portSpec)
+
+	expectedOpenedPort := EnvOpenedPort{
+		PortSpec: portSpec,
+		EnvOpenedPortMetadata: EnvOpenedPortMetadata{
+			Label:              "webpack dev server",
+			OpenedAtTimestamp:  1650000000,
+			OpenedBy:           "jane",
+			ExpiresAtTimestamp: 1650003600,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedOpenedPort, env.OpenedPorts["8080"]) {
+		t.Fatalf(
+			"expected opened port to equal '%+v', got '%+v'",
+			expectedOpenedPort,
+			env.OpenedPorts["8080"],
+		)
+	}
+}
+
+func TestGetExpiredPorts(t *testing.T) {
+	now := time.Unix(1650000000, 0)
+	env := &Env{}
+
+	testCases := []struct {
+		port string
+		ttl  time.Duration
+	}{
+		{port: "8080", ttl: -time.Minute},
+		{port: "3000", ttl: time.Hour},
+		{port: "4000", ttl: 0},
+		{port: "5000/udp", ttl: -time.Hour},
+	}
+
+	for _, tc := range testCases {
+		portSpec, err := ParsePortSpec(tc.port)
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		env.SetPortAsOpened(portSpec)
+
+		metadata := EnvOpenedPortMetadata{}
+
+		// Zero TTL means that the port never expires
+		if tc.ttl != 0 {
+			metadata.ExpiresAtTimestamp = now.Add(tc.ttl).Unix()
+		}
+
+		env.SetOpenedPortMetadata(portSpec, metadata)
+	}
+
+	expiredPorts := env.GetExpiredPorts(now)
+	expiredPortSpecs := []string{}
+
+	for _, expiredPort := range expiredPorts {
+		expiredPortSpecs = append(expiredPortSpecs, expiredPort.String())
+	}
+
+	expectedExpiredPortSpecs := []string{"5000/udp", "8080"}
+
+	if !reflect.DeepEqual(expectedExpiredPortSpecs, expiredPortSpecs) {
+		t.Fatalf(
+			"expected expired ports to equal '%+v', got '%+v'",
+			expectedExpiredPortSpecs,
+			expiredPortSpecs,
+		)
+	}
+}
+
+func TestAdoptObservedStateKeepsOpenedPortMetadata(t *testing.T) {
+	env := &Env{
+		OpenedPorts: map[string]EnvOpenedPort{
+			"8080": {
+				PortSpec: NewTCPPortSpec(8080),
+				EnvOpenedPortMetadata: EnvOpenedPortMetadata{
+					Label: "webpack dev server",
+				},
+			},
+		},
+	}
+
+	env.AdoptObservedState(&EnvObservedState{
+		SSHHostKeys: []EnvSSHHostKey{},
+		OpenedPorts: map[string]PortSpec{
+			"8080": NewTCPPortSpec(8080),
+			"3000": NewTCPPortSpec(3000),
+		},
+	})
+
+	expectedOpenedPorts := map[string]EnvOpenedPort{
+		"8080": {
+			PortSpec: NewTCPPortSpec(8080),
+			EnvOpenedPortMetadata: EnvOpenedPortMetadata{
+				Label: "webpack dev server",
+			},
+		},
+
+		"3000": {PortSpec: NewTCPPortSpec(3000)},
+	}
+
+	if !reflect.DeepEqual(expectedOpenedPorts, env.OpenedPorts) {
+		t.Fatalf(
+			"expected opened ports to equal '%+v', got '%+v'",
+			expectedOpenedPorts,
+			env.OpenedPorts,
+		)
+	}
+}
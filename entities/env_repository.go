@@ -1,12 +1,84 @@
 package entities
 
+import (
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+// DefaultEnvRepositoryHost is the host omitted from env names
+// so that the envs created before the hosts were recorded keep
+// their names (see "BuildEnvNameFromResolvedRepo").
+const DefaultEnvRepositoryHost = "github.com"
+
+// EnvRepositoryVariantSeparator separates the repository
+// from the variant in env names (eg: "yolo-sh/yolo@feature-x").
+const EnvRepositoryVariantSeparator = "@"
+
 type EnvRepositoryGitURL string
 
+type EnvRepositoryRefType string
+
+const (
+	EnvRepositoryRefTypeBranch EnvRepositoryRefType = "branch"
+	EnvRepositoryRefTypeTag    EnvRepositoryRefType = "tag"
+	EnvRepositoryRefTypeCommit EnvRepositoryRefType = "commit"
+)
+
 type ResolvedEnvRepository struct {
-	Name          string              `json:"name"`
-	Owner         string              `json:"owner"`
-	ExplicitOwner bool                `json:"explicit_owner"`
-	GitURL        EnvRepositoryGitURL `json:"git_url"`
-	GitHTTPURL    EnvRepositoryGitURL `json:"git_http_url"`
-	LanguagesUsed []string            `json:"languages_used"`
+	Host          string               `json:"host"`
+	Name          string               `json:"name"`
+	Owner         string               `json:"owner"`
+	ExplicitOwner bool                 `json:"explicit_owner"`
+	GitURL        EnvRepositoryGitURL  `json:"git_url"`
+	GitHTTPURL    EnvRepositoryGitURL  `json:"git_http_url"`
+	LanguagesUsed []string             `json:"languages_used"`
+	Languages     []RepositoryLanguage `json:"languages"`
+	SizeBytes     int64                `json:"size_bytes"`
+	Variant       string               `json:"variant"`
+	Ref           string               `json:"ref"`
+	RefType       EnvRepositoryRefType `json:"ref_type"`
+	CommitSHA     string               `json:"commit_sha"`
+}
+
+// ResolvedEnvRepositoryRef represents a branch, a tag
+// or a commit resolved to the commit SHA it points to.
+type ResolvedEnvRepositoryRef struct {
+	Ref       string
+	RefType   EnvRepositoryRefType
+	CommitSHA string
+}
+
+// PinRef records the passed ref so that the env
+// is built from the commit it was resolved to.
+func (r *ResolvedEnvRepository) PinRef(resolvedRef ResolvedEnvRepositoryRef) {
+	r.Ref = resolvedRef.Ref
+	r.RefType = resolvedRef.RefType
+	r.CommitSHA = resolvedRef.CommitSHA
+}
+
+// GetPinnedRef returns the commit SHA that the repository is
+// pinned to or the requested ref when not resolved yet.
+//
+// Empty means that the default branch is used.
+func (r ResolvedEnvRepository) GetPinnedRef() string {
+	if len(r.CommitSHA) > 0 {
+		return r.CommitSHA
+	}
+
+	return r.Ref
+}
+
+func CheckEnvRepositoryVariantValidity(variant string) error {
+	valid := len(slug.Make(variant)) > 0 &&
+		!strings.Contains(variant, EnvRepositoryVariantSeparator) &&
+		!strings.ContainsAny(variant, " \t\n")
+
+	if !valid {
+		return ErrInvalidEnvRepositoryVariant{
+			Variant: variant,
+		}
+	}
+
+	return nil
 }
@@ -5,6 +5,32 @@ type ErrEnvRepositoryNotFound struct {
 	RepoName  string
 }
 
+type ErrEnvRepositoryRefNotFound struct {
+	RepoOwner string
+	RepoName  string
+	Ref       string
+}
+
+func (ErrEnvRepositoryRefNotFound) Error() string {
+	return "ErrEnvRepositoryRefNotFound"
+}
+
+type ErrInvalidEnvRepositoryVariant struct {
+	Variant string
+}
+
+func (ErrInvalidEnvRepositoryVariant) Error() string {
+	return "ErrInvalidEnvRepositoryVariant"
+}
+
 func (ErrEnvRepositoryNotFound) Error() string {
 	return "ErrEnvRepositoryNotFound"
 }
+
+type ErrVCSProviderNotFound struct {
+	Host string
+}
+
+func (ErrVCSProviderNotFound) Error() string {
+	return "ErrVCSProviderNotFound"
+}
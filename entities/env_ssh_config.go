@@ -0,0 +1,186 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SSHConfigManagedSectionStartMarker = "# BEGIN YOLO MANAGED SECTION (do not edit)"
+	SSHConfigManagedSectionEndMarker   = "# END YOLO MANAGED SECTION"
+)
+
+type EnvSSHConfigOptions struct {
+	// Empty means that the default SSH port is used
+	Port             string
+	User             string
+	IdentityFilePath string
+	// Empty means that the default "known_hosts" files are used
+	KnownHostsFilePath string
+}
+
+// GetSSHHostKeyAlias returns the name used to look up the env host keys
+// in the "known_hosts" files (see "BuildSSHConfigHostBlock").
+//
+// Unlike the public IP address, the alias doesn't
+// change when the env instance is restarted.
+func (e *Env) GetSSHHostKeyAlias() string {
+	return e.GetNameSlug()
+}
+
+// BuildHostKeyAliasKnownHostsEntries returns the "known_hosts" lines
+// that match the "HostKeyAlias" set in the env SSH config.
+func (e *Env) BuildHostKeyAliasKnownHostsEntries() ([]string, error) {
+	return e.buildKnownHostsEntriesForAddress(
+		e.GetSSHHostKeyAlias(),
+		false,
+	)
+}
+
+// BuildSSHConfigHostBlock returns the OpenSSH config "Host" block used
+// to connect to the env with "ssh <env_name_slug>".
+//
+// The single TCP ports opened in the env
+// are forwarded to the same local ports.
+func (e *Env) BuildSSHConfigHostBlock(options EnvSSHConfigOptions) (string, error) {
+	if len(e.InstancePublicIPAddress) == 0 {
+		return "", ErrEnvMissingPublicIPAddress{
+			EnvName: e.Name,
+		}
+	}
+
+	port := options.Port
+
+	if len(port) == 0 {
+		port = DefaultEnvSSHPort
+	}
+
+	lines := []string{
+		"Host " + e.GetNameSlug(),
+		"  HostName " + e.InstancePublicIPAddress,
+		"  Port " + port,
+	}
+
+	if len(options.User) > 0 {
+		lines = append(lines, "  User "+options.User)
+	}
+
+	if len(options.IdentityFilePath) > 0 {
+		lines = append(
+			lines,
+			"  IdentityFile "+quoteSSHConfigValue(options.IdentityFilePath),
+			"  IdentitiesOnly yes",
+		)
+	}
+
+	lines = append(lines, "  HostKeyAlias "+e.GetSSHHostKeyAlias())
+
+	if len(options.KnownHostsFilePath) > 0 {
+		lines = append(
+			lines,
+			"  UserKnownHostsFile "+quoteSSHConfigValue(options.KnownHostsFilePath),
+			"  StrictHostKeyChecking yes",
+		)
+	}
+
+	for _, openedPort := range e.getSortedOpenedPorts() {
+		lines = append(
+			lines,
+			"  LocalForward "+openedPort+" localhost:"+openedPort,
+		)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (e *Env) getSortedOpenedPorts() []string {
+	uniquePorts := map[int]bool{}
+	openedPorts := []int{}
+
+	for _, portSpec := range e.OpenedPorts {
+		// Ranges may contain thousands of ports
+		if portSpec.Protocol != PortProtocolTCP || portSpec.IsRange() ||
+			uniquePorts[portSpec.FromPort] {
+
+			continue
+		}
+
+		uniquePorts[portSpec.FromPort] = true
+		openedPorts = append(openedPorts, portSpec.FromPort)
+	}
+
+	sort.Ints(openedPorts)
+
+	sortedOpenedPorts := []string{}
+
+	for _, port := range openedPorts {
+		sortedOpenedPorts = append(sortedOpenedPorts, strconv.Itoa(port))
+	}
+
+	return sortedOpenedPorts
+}
+
+func quoteSSHConfigValue(value string) string {
+	if !strings.ContainsAny(value, " \t") {
+		return value
+	}
+
+	return "\"" + value + "\""
+}
+
+// ReplaceSSHConfigManagedSection replaces the content between the
+// managed section markers in the passed SSH config with the passed
+// host blocks. The rest of the config is left untouched.
+//
+// The section is appended when missing (so that the global options
+// at the top of the config keep applying to all hosts) and removed
+// when no host blocks are passed.
+func ReplaceSSHConfigManagedSection(
+	configContent string,
+	hostBlocks []string,
+) (string, error) {
+
+	startIndex := strings.Index(configContent, SSHConfigManagedSectionStartMarker)
+	endIndex := strings.Index(configContent, SSHConfigManagedSectionEndMarker)
+
+	if (startIndex == -1) != (endIndex == -1) || endIndex < startIndex {
+		return "", ErrInvalidSSHConfigManagedSection{}
+	}
+
+	before := configContent
+	after := ""
+
+	if startIndex != -1 {
+		before = configContent[:startIndex]
+		after = configContent[endIndex+len(SSHConfigManagedSectionEndMarker):]
+	}
+
+	before = strings.TrimRight(before, "\n")
+	after = strings.TrimLeft(after, "\n")
+
+	sections := []string{}
+
+	if len(before) > 0 {
+		sections = append(sections, before)
+	}
+
+	if len(hostBlocks) > 0 {
+		sections = append(
+			sections,
+			SSHConfigManagedSectionStartMarker+"\n\n"+
+				strings.Join(hostBlocks, "\n\n")+"\n\n"+
+				SSHConfigManagedSectionEndMarker,
+		)
+	}
+
+	if len(after) > 0 {
+		sections = append(sections, strings.TrimRight(after, "\n"))
+	}
+
+	if len(sections) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(sections, "\n\n") + "\n", nil
+}
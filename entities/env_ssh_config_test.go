@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildSSHConfigHostBlock(t *testing.T) {
+	env := &Env{
+		Name:                    "yolo-sh/yolo",
+		InstancePublicIPAddress: "10.0.0.179",
+		OpenedPorts:             map[string]EnvOpenedPort{},
+	}
+
+	for _, port := range []string{"8080", "443", "8000-8010", "3000/udp"} {
+		portSpec, err := ParsePortSpec(port)
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		env.SetPortAsOpened(portSpec)
+	}
+
+	hostBlock, err := env.BuildSSHConfigHostBlock(EnvSSHConfigOptions{
+		Port:               "2200",
+		User:               "yolo",
+		IdentityFilePath:   "/home/jane doe/.ssh/yolo-sh-yolo",
+		KnownHostsFilePath: "/home/jane/.ssh/yolo_known_hosts",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedHostBlock := `Host yolo-sh-yolo
+  HostName 10.0.0.179
+  Port 2200
+  User yolo
+  IdentityFile "/home/jane doe/.ssh/yolo-sh-yolo"
+  IdentitiesOnly yes
+  HostKeyAlias yolo-sh-yolo
+  UserKnownHostsFile /home/jane/.ssh/yolo_known_hosts
+  StrictHostKeyChecking yes
+  LocalForward 443 localhost:443
+  LocalForward 8080 localhost:8080`
+
+	if hostBlock != expectedHostBlock {
+		t.Fatalf("expected host block to equal '%s', got '%s'", expectedHostBlock, hostBlock)
+	}
+}
+
+func TestReplaceSSHConfigManagedSection(t *testing.T) {
+	managedSection := SSHConfigManagedSectionStartMarker + "\n\nHost a\n  Port 22\n\n" +
+		SSHConfigManagedSectionEndMarker + "\n"
+
+	testCases := []struct {
+		test            string
+		configContent   string
+		hostBlocks      []string
+		expectedContent string
+	}{
+		{
+			test:            "with empty config",
+			configContent:   "",
+			hostBlocks:      []string{"Host a\n  Port 22"},
+			expectedContent: managedSection,
+		},
+
+		{
+			test:            "with config without managed section",
+			configContent:   "Host *\n  ServerAliveInterval 60\n",
+			hostBlocks:      []string{"Host a\n  Port 22"},
+			expectedContent: "Host *\n  ServerAliveInterval 60\n\n" + managedSection,
+		},
+
+		{
+			test: "with existing managed section",
+			configContent: "Host *\n  ServerAliveInterval 60\n\n" +
+				SSHConfigManagedSectionStartMarker + "\n\nHost b\n  Port 22\n\n" +
+				SSHConfigManagedSectionEndMarker + "\n\nHost c\n  Port 22\n",
+			hostBlocks: []string{"Host a\n  Port 22"},
+			expectedContent: "Host *\n  ServerAliveInterval 60\n\n" +
+				SSHConfigManagedSectionStartMarker + "\n\nHost a\n  Port 22\n\n" +
+				SSHConfigManagedSectionEndMarker + "\n\nHost c\n  Port 22\n",
+		},
+
+		{
+			test:            "without host blocks",
+			configContent:   "Host *\n  ServerAliveInterval 60\n\n" + managedSection,
+			hostBlocks:      []string{},
+			expectedContent: "Host *\n  ServerAliveInterval 60\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			content, err := ReplaceSSHConfigManagedSection(tc.configContent, tc.hostBlocks)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if content != tc.expectedContent {
+				t.Fatalf("expected content to equal '%s', got '%s'", tc.expectedContent, content)
+			}
+
+			// Replacing twice must not change the content
+			contentReplacedTwice, err := ReplaceSSHConfigManagedSection(content, tc.hostBlocks)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if contentReplacedTwice != content {
+				t.Fatalf("expected content to equal '%s', got '%s'", content, contentReplacedTwice)
+			}
+		})
+	}
+}
+
+func TestReplaceSSHConfigManagedSectionWithMissingEndMarker(t *testing.T) {
+	_, err := ReplaceSSHConfigManagedSection(
+		SSHConfigManagedSectionStartMarker+"\n\nHost a\n",
+		[]string{},
+	)
+
+	if !errors.As(err, &ErrInvalidSSHConfigManagedSection{}) {
+		t.Fatalf("expected invalid managed section error, got '%+v'", err)
+	}
+}
@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DefaultEnvSSHPort is the port used in the "known_hosts"
+// entries when no port is passed.
+const DefaultEnvSSHPort = "22"
+
+type EnvSSHHostKey struct {
+	Algorithm string `json:"algorithm"`
+	// SHA256 fingerprint as displayed by OpenSSH (eg: "SHA256:...")
+	Fingerprint string `json:"fingerprint"`
+	// Base64-encoded public key as found in "authorized_keys" files
+	PublicKey string `json:"public_key"`
+}
+
+// UnmarshalJSON migrates the host keys stored before the public
+// keys were kept. In these keys, the fingerprint field contains
+// the base64-encoded public key instead of the fingerprint.
+func (e *EnvSSHHostKey) UnmarshalJSON(data []byte) error {
+	// Prevents infinite recursion
+	type envSSHHostKey EnvSSHHostKey
+
+	var hostKey envSSHHostKey
+
+	if err := json.Unmarshal(data, &hostKey); err != nil {
+		return err
+	}
+
+	*e = EnvSSHHostKey(hostKey)
+
+	if len(e.PublicKey) > 0 || strings.HasPrefix(e.Fingerprint, "SHA256:") {
+		return nil
+	}
+
+	publicKey, err := parseBase64SSHPublicKey(e.Fingerprint)
+
+	// The legacy values that are not valid keys are kept as is
+	if err != nil {
+		return nil
+	}
+
+	e.Fingerprint = ssh.FingerprintSHA256(publicKey)
+	e.PublicKey = strings.TrimSpace(hostKey.Fingerprint)
+
+	return nil
+}
+
+func parseBase64SSHPublicKey(base64PublicKey string) (ssh.PublicKey, error) {
+	publicKeyBytes, err := base64.StdEncoding.DecodeString(
+		strings.TrimSpace(base64PublicKey),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.ParsePublicKey(publicKeyBytes)
+}
+
+// ParseSSHHostKeys parses the passed host keys in the OpenSSH public key format
+// (eg: the content of the "/etc/ssh/ssh_host_*_key.pub" files).
+//
+// Options, comments (with or without spaces), empty lines
+// and lines starting with "#" are supported.
+func ParseSSHHostKeys(hostKeysContent string) ([]EnvSSHHostKey, error) {
+	parsedHostKeys := []EnvSSHHostKey{}
+	scanner := bufio.NewScanner(strings.NewReader(hostKeysContent))
+
+	for scanner.Scan() {
+		hostKey := strings.TrimSpace(scanner.Text())
+
+		if len(hostKey) == 0 || strings.HasPrefix(hostKey, "#") {
+			continue
+		}
+
+		// eg: [options] (ssh-rsa) (AAAAB3NzaC1yc===) [root@ip-10-0-0-200]
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid host key (\"%s\")", hostKey)
+		}
+
+		parsedHostKeys = append(parsedHostKeys, EnvSSHHostKey{
+			Algorithm:   publicKey.Type(),
+			Fingerprint: ssh.FingerprintSHA256(publicKey),
+			PublicKey:   base64.StdEncoding.EncodeToString(publicKey.Marshal()),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(parsedHostKeys) == 0 {
+		return nil, fmt.Errorf("no host keys found (\"%s\")", hostKeysContent)
+	}
+
+	return parsedHostKeys, nil
+}
+
+// BuildKnownHostsEntries returns the "known_hosts" lines used to verify
+// the env host keys when connecting to its public IP address.
+// An empty port means that the default SSH port is used.
+func (e *Env) BuildKnownHostsEntries(port string) ([]string, error) {
+	return e.buildKnownHostsEntries(port, false)
+}
+
+// BuildHashedKnownHostsEntries is like "BuildKnownHostsEntries"
+// but with hashed hostnames (like with "HashKnownHosts yes" in OpenSSH).
+//
+// The hashes are salted so the entries differ between calls.
+func (e *Env) BuildHashedKnownHostsEntries(port string) ([]string, error) {
+	return e.buildKnownHostsEntries(port, true)
+}
+
+func (e *Env) buildKnownHostsEntries(port string, hashed bool) ([]string, error) {
+	if len(e.InstancePublicIPAddress) == 0 {
+		return nil, ErrEnvMissingPublicIPAddress{
+			EnvName: e.Name,
+		}
+	}
+
+	if len(port) == 0 {
+		port = DefaultEnvSSHPort
+	}
+
+	// Port 22 is omitted, other ports give "[<ip>]:<port>"
+	address := knownhosts.Normalize(
+		net.JoinHostPort(e.InstancePublicIPAddress, port),
+	)
+
+	return e.buildKnownHostsEntriesForAddress(address, hashed)
+}
+
+func (e *Env) buildKnownHostsEntriesForAddress(
+	address string,
+	hashed bool,
+) ([]string, error) {
+
+	if hashed {
+		address = knownhosts.HashHostname(address)
+	}
+
+	entries := []string{}
+
+	for _, hostKey := range e.SSHHostKeys {
+		publicKey, err := parseBase64SSHPublicKey(hostKey.PublicKey)
+
+		if err != nil {
+			return nil, ErrInvalidEnvSSHHostKey{
+				EnvName:   e.Name,
+				Algorithm: hostKey.Algorithm,
+			}
+		}
+
+		entries = append(entries, knownhosts.Line(
+			[]string{address},
+			publicKey,
+		))
+	}
+
+	return entries, nil
+}
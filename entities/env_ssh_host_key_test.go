@@ -0,0 +1,181 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestUnmarshalLegacySSHHostKey(t *testing.T) {
+	legacyHostKeyJSON := `{"algorithm": "ssh-ed25519", "fingerprint": "` + testEd25519SSHHostKey + `"}`
+
+	var hostKey EnvSSHHostKey
+	err := json.Unmarshal([]byte(legacyHostKeyJSON), &hostKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedHostKey := EnvSSHHostKey{
+		Algorithm:   "ssh-ed25519",
+		Fingerprint: "SHA256:9P0qbHyBYNqDoe0npM+A61mswxBjDSjFjsH1lGiYf+o",
+		PublicKey:   testEd25519SSHHostKey,
+	}
+
+	if !reflect.DeepEqual(expectedHostKey, hostKey) {
+		t.Fatalf(
+			"expected SSH host key to equal '%+v', got '%+v'",
+			expectedHostKey,
+			hostKey,
+		)
+	}
+}
+
+func TestBuildKnownHostsEntries(t *testing.T) {
+	hostKeys, err := ParseSSHHostKeys(
+		"ssh-ed25519 " + testEd25519SSHHostKey + "\nssh-rsa " + testRSASSHHostKey,
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	env := &Env{
+		Name:                    "yolo-sh/yolo",
+		InstancePublicIPAddress: "10.0.0.179",
+		SSHHostKeys:             hostKeys,
+	}
+
+	testCases := []struct {
+		test            string
+		port            string
+		expectedEntries []string
+	}{
+		{
+			test: "with default port",
+			port: "",
+			expectedEntries: []string{
+				"10.0.0.179 ssh-ed25519 " + testEd25519SSHHostKey,
+				"10.0.0.179 ssh-rsa " + testRSASSHHostKey,
+			},
+		},
+
+		{
+			test: "with custom port",
+			port: "2200",
+			expectedEntries: []string{
+				"[10.0.0.179]:2200 ssh-ed25519 " + testEd25519SSHHostKey,
+				"[10.0.0.179]:2200 ssh-rsa " + testRSASSHHostKey,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			entries, err := env.BuildKnownHostsEntries(tc.port)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedEntries, entries) {
+				t.Fatalf(
+					"expected entries to equal '%+v', got '%+v'",
+					tc.expectedEntries,
+					entries,
+				)
+			}
+		})
+	}
+}
+
+func TestBuildHashedKnownHostsEntries(t *testing.T) {
+	hostKeys, err := ParseSSHHostKeys("ssh-ed25519 " + testEd25519SSHHostKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	env := &Env{
+		Name:                    "yolo-sh/yolo",
+		InstancePublicIPAddress: "10.0.0.179",
+		SSHHostKeys:             hostKeys,
+	}
+
+	entries, err := env.BuildHashedKnownHostsEntries("2200")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if len(entries) != 1 || !strings.HasPrefix(entries[0], "|1|") {
+		t.Fatalf("expected one hashed entry, got '%+v'", entries)
+	}
+
+	knownHostsFilePath := filepath.Join(t.TempDir(), "known_hosts")
+	err = os.WriteFile(knownHostsFilePath, []byte(entries[0]+"\n"), 0600)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	hostKeyCallback, err := knownhosts.New(knownHostsFilePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	publicKey, err := parseBase64SSHPublicKey(testEd25519SSHHostKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = hostKeyCallback(
+		"10.0.0.179:2200",
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.179"), Port: 2200},
+		publicKey,
+	)
+
+	if err != nil {
+		t.Fatalf("expected host key to be verified, got '%+v'", err)
+	}
+
+	otherPublicKey, err := parseBase64SSHPublicKey(testRSASSHHostKey)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = hostKeyCallback(
+		"10.0.0.179:2200",
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.179"), Port: 2200},
+		otherPublicKey,
+	)
+
+	var keyErr *knownhosts.KeyError
+
+	if !errors.As(err, &keyErr) {
+		t.Fatalf("expected key error, got '%+v'", err)
+	}
+}
+
+func TestBuildKnownHostsEntriesWithoutPublicIPAddress(t *testing.T) {
+	env := &Env{
+		Name:        "yolo-sh/yolo",
+		SSHHostKeys: []EnvSSHHostKey{},
+	}
+
+	_, err := env.BuildKnownHostsEntries("")
+
+	if !errors.As(err, &ErrEnvMissingPublicIPAddress{}) {
+		t.Fatalf("expected missing public IP address error, got '%+v'", err)
+	}
+}
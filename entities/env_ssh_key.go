@@ -0,0 +1,65 @@
+package entities
+
+// EnvSSHKeyStrategy represents how the env SSH key
+// is granted access to the env repository.
+type EnvSSHKeyStrategy string
+
+const (
+	// The key is registered on the user account and
+	// grants access to all the repositories of the user
+	EnvSSHKeyStrategyUserKey EnvSSHKeyStrategy = "user_key"
+	// The key is registered as a deploy key and
+	// only grants access to the env repository
+	EnvSSHKeyStrategyDeployKey EnvSSHKeyStrategy = "deploy_key"
+)
+
+type EnvRepositoryAccessMode string
+
+const (
+	EnvRepositoryAccessModeReadWrite EnvRepositoryAccessMode = "read_write"
+	EnvRepositoryAccessModeReadOnly  EnvRepositoryAccessMode = "read_only"
+)
+
+func CheckEnvSSHKeyStrategyValidity(strategy EnvSSHKeyStrategy) error {
+	if strategy != EnvSSHKeyStrategyUserKey &&
+		strategy != EnvSSHKeyStrategyDeployKey {
+
+		return ErrInvalidEnvSSHKeyStrategy{
+			Strategy: strategy,
+		}
+	}
+
+	return nil
+}
+
+func CheckEnvRepositoryAccessModeValidity(accessMode EnvRepositoryAccessMode) error {
+	if accessMode != EnvRepositoryAccessModeReadWrite &&
+		accessMode != EnvRepositoryAccessModeReadOnly {
+
+		return ErrInvalidEnvRepositoryAccessMode{
+			AccessMode: accessMode,
+		}
+	}
+
+	return nil
+}
+
+// GetSSHKeyStrategy returns the SSH key strategy of the env.
+// The envs created before the strategies were introduced use user keys.
+func (e *Env) GetSSHKeyStrategy() EnvSSHKeyStrategy {
+	if len(e.SSHKeyStrategy) == 0 {
+		return EnvSSHKeyStrategyUserKey
+	}
+
+	return e.SSHKeyStrategy
+}
+
+// GetRepositoryAccessMode returns the repository access mode
+// of the env. Read-write access is used when not set.
+func (e *Env) GetRepositoryAccessMode() EnvRepositoryAccessMode {
+	if len(e.RepositoryAccessMode) == 0 {
+		return EnvRepositoryAccessModeReadWrite
+	}
+
+	return e.RepositoryAccessMode
+}
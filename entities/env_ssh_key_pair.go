@@ -0,0 +1,141 @@
+package entities
+
+// EnvSSHKeyPairAlgorithm represents the algorithm
+// of the key pair used to access the env.
+type EnvSSHKeyPairAlgorithm string
+
+const (
+	EnvSSHKeyPairAlgorithmEd25519 EnvSSHKeyPairAlgorithm = "ed25519"
+	EnvSSHKeyPairAlgorithmECDSA   EnvSSHKeyPairAlgorithm = "ecdsa"
+	EnvSSHKeyPairAlgorithmRSA     EnvSSHKeyPairAlgorithm = "rsa"
+)
+
+// DefaultEnvSSHKeyPairAlgorithm is the algorithm used for the new envs.
+const DefaultEnvSSHKeyPairAlgorithm = EnvSSHKeyPairAlgorithmEd25519
+
+// The sizes in bits supported for each algorithm.
+// The first one is used when the size is not set.
+var envSSHKeyPairSizes = map[EnvSSHKeyPairAlgorithm][]int{
+	EnvSSHKeyPairAlgorithmEd25519: {256},
+	EnvSSHKeyPairAlgorithmECDSA:   {256, 384, 521},
+	EnvSSHKeyPairAlgorithmRSA:     {4096, 2048, 3072, 8192},
+}
+
+// EnvSSHKeyPair represents a key pair used to access an env.
+type EnvSSHKeyPair struct {
+	Algorithm EnvSSHKeyPairAlgorithm
+	Bits      int
+	// PEM-encoded private key (see "Env.SSHKeyPairPEMContent")
+	PEMContent string
+	// Public key in the "authorized_keys" format
+	PublicKeyContent string
+}
+
+func CheckEnvSSHKeyPairAlgorithmValidity(algorithm EnvSSHKeyPairAlgorithm) error {
+	if _, algorithmExists := envSSHKeyPairSizes[algorithm]; !algorithmExists {
+		return ErrInvalidEnvSSHKeyPairAlgorithm{
+			Algorithm: algorithm,
+		}
+	}
+
+	return nil
+}
+
+// CheckEnvSSHKeyPairSizeValidity checks that the passed size
+// is supported by the passed algorithm. Zero is always valid.
+func CheckEnvSSHKeyPairSizeValidity(
+	algorithm EnvSSHKeyPairAlgorithm,
+	bits int,
+) error {
+
+	err := CheckEnvSSHKeyPairAlgorithmValidity(algorithm)
+
+	if err != nil {
+		return err
+	}
+
+	if bits == 0 {
+		return nil
+	}
+
+	for _, supportedBits := range envSSHKeyPairSizes[algorithm] {
+		if bits == supportedBits {
+			return nil
+		}
+	}
+
+	return ErrInvalidEnvSSHKeyPairSize{
+		Algorithm: algorithm,
+		Bits:      bits,
+	}
+}
+
+// GetDefaultEnvSSHKeyPairSize returns the size in bits
+// used when generating a key pair without explicit size.
+func GetDefaultEnvSSHKeyPairSize(algorithm EnvSSHKeyPairAlgorithm) int {
+	sizes := envSSHKeyPairSizes[algorithm]
+
+	if len(sizes) == 0 {
+		return 0
+	}
+
+	return sizes[0]
+}
+
+// GetSSHKeyPairAlgorithm returns the algorithm of the env key pair.
+// The envs created before the algorithms were recorded use RSA keys.
+func (e *Env) GetSSHKeyPairAlgorithm() EnvSSHKeyPairAlgorithm {
+	if len(e.SSHKeyPairAlgorithm) == 0 {
+		return EnvSSHKeyPairAlgorithmRSA
+	}
+
+	return e.SSHKeyPairAlgorithm
+}
+
+// GetSSHKeyPairBits returns the size in bits of the env key pair
+// or the default size of the env algorithm when not set.
+func (e *Env) GetSSHKeyPairBits() int {
+	if e.SSHKeyPairBits == 0 {
+		return GetDefaultEnvSSHKeyPairSize(e.GetSSHKeyPairAlgorithm())
+	}
+
+	return e.SSHKeyPairBits
+}
+
+// GetSSHKeyPair returns the current env key pair (see "SetSSHKeyPair").
+func (e *Env) GetSSHKeyPair() EnvSSHKeyPair {
+	return EnvSSHKeyPair{
+		Algorithm:        e.SSHKeyPairAlgorithm,
+		Bits:             e.SSHKeyPairBits,
+		PEMContent:       e.SSHKeyPairPEMContent,
+		PublicKeyContent: e.SSHPublicKeyContent,
+	}
+}
+
+// SetSSHKeyPair replaces the env key pair
+// and its metadata with the passed one.
+func (e *Env) SetSSHKeyPair(keyPair EnvSSHKeyPair) {
+	e.SSHKeyPairAlgorithm = keyPair.Algorithm
+	e.SSHKeyPairBits = keyPair.Bits
+	e.SSHKeyPairPEMContent = keyPair.PEMContent
+	e.SSHPublicKeyContent = keyPair.PublicKeyContent
+}
+
+// GetSSHKeyPairName returns the name used for the env key pair
+// in the cloud provider and in the VCS provider.
+//
+// The name includes the algorithm (eg: "yolo-<env_name_slug>-ed25519-key-pair")
+// except for the envs created before the algorithms were recorded.
+func (e *Env) GetSSHKeyPairName() string {
+	return e.BuildSSHKeyPairName(e.SSHKeyPairAlgorithm)
+}
+
+// BuildSSHKeyPairName returns the name that the env key pair
+// would have with the passed algorithm (see "GetSSHKeyPairName").
+func (e *Env) BuildSSHKeyPairName(algorithm EnvSSHKeyPairAlgorithm) string {
+	if len(algorithm) == 0 {
+		return "yolo-" + e.GetNameSlug() + "-key-pair"
+	}
+
+	return "yolo-" + e.GetNameSlug() + "-" + string(algorithm) + "-key-pair"
+}
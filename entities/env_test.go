@@ -4,27 +4,39 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
+)
+
+const (
+	testECDSASSHHostKey   = "AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBIzG8XAmcHWMO9Cm2tvqyToDAjrq+aZxpq6ia5gvoBVkDa6n0BzWY1BEO6m+Xr/PimTMlgoryaxqGBsc59jQTQk="
+	testEd25519SSHHostKey = "AAAAC3NzaC1lZDI1NTE5AAAAIJ4bxhREDo2B+6qriOZjmdOM7piS7oRXacL4lDNZf1zE"
+	testRSASSHHostKey     = "AAAAB3NzaC1yc2EAAAADAQABAAAAgQC5I6qyTESqv85VokqAxwBWShXq5R4oN57NWQwZBLN5VnEtXqKXEci/SCmxuZOKPEYcQ5p+Pu+AqyybDbMIBN9vYoEJUzMfvEHzuecdwAKVIWAGvkgvFTsRxAolAhz5mowrE5it5IpChBp103ykzChBwtJwrZq5flokIotiv7PMEQ=="
 )
 
 func TestParseValidSSHHostKeys(t *testing.T) {
-	givenSSHHostKeysContent := `ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzd root@ip-10-0-0-179
-	ssh-ed25519 AAAAC3NzaC1lZD root@ip-10-0-0-179
-	ssh-rsa AAAAB3NzaC root@ip-10-0-0-179
+	givenSSHHostKeysContent := `# Host keys of ip-10-0-0-179
+	ecdsa-sha2-nistp256 ` + testECDSASSHHostKey + ` root@ip-10-0-0-179
+
+	ssh-ed25519 ` + testEd25519SSHHostKey + `
+	ssh-rsa ` + testRSASSHHostKey + ` root@ip-10-0-0-179 host key
 `
 	expectedSSHHostKeys := []EnvSSHHostKey{
 		{
 			Algorithm:   "ecdsa-sha2-nistp256",
-			Fingerprint: "AAAAE2VjZHNhLXNoYTItbmlzd",
+			Fingerprint: "SHA256:J5FgCFtDWpViGZCtufe+DnLDNcHkqgP+cqDTUefK3DQ",
+			PublicKey:   testECDSASSHHostKey,
 		},
 
 		{
 			Algorithm:   "ssh-ed25519",
-			Fingerprint: "AAAAC3NzaC1lZD",
+			Fingerprint: "SHA256:9P0qbHyBYNqDoe0npM+A61mswxBjDSjFjsH1lGiYf+o",
+			PublicKey:   testEd25519SSHHostKey,
 		},
 
 		{
 			Algorithm:   "ssh-rsa",
-			Fingerprint: "AAAAB3NzaC",
+			Fingerprint: "SHA256:vMjQa3yN8toyWxfgDKayIoEn+U1qg7l7dI9+RAy15XE",
+			PublicKey:   testRSASSHHostKey,
 		},
 	}
 
@@ -174,3 +186,309 @@ func TestCheckPortValidityWithReservedPorts(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvTTL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	env := &Env{}
+
+	if env.HasExpired(now) {
+		t.Fatalf("expected env without TTL to never expire")
+	}
+
+	env.SetTTL(time.Hour, now)
+
+	if env.HasExpired(now.Add(59 * time.Minute)) {
+		t.Fatalf("expected env to not be expired before its TTL")
+	}
+
+	if !env.HasExpired(now.Add(time.Hour)) {
+		t.Fatalf("expected env to be expired after its TTL")
+	}
+
+	err := env.ExtendTTL(time.Hour, now)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.ExpiresAtTimestamp != now.Add(2*time.Hour).Unix() {
+		t.Fatalf(
+			"expected env to expire at '%d', got '%d'",
+			now.Add(2*time.Hour).Unix(),
+			env.ExpiresAtTimestamp,
+		)
+	}
+
+	err = env.ExtendTTL(time.Hour, now.Add(3*time.Hour))
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if env.ExpiresAtTimestamp != now.Add(4*time.Hour).Unix() {
+		t.Fatalf(
+			"expected expired env to be extended from now ('%d'), got '%d'",
+			now.Add(4*time.Hour).Unix(),
+			env.ExpiresAtTimestamp,
+		)
+	}
+}
+
+func TestEnvExtendTTLWithInvalidExtension(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	env := &Env{}
+
+	err := env.ExtendTTL(time.Hour, now)
+
+	if !errors.As(err, &ErrExtendEnvWithoutTTL{}) {
+		t.Fatalf("expected env without TTL to not be extended, got '%+v'", err)
+	}
+
+	if env.ExpiresAtTimestamp != 0 {
+		t.Fatalf("expected env to never expire, got '%d'", env.ExpiresAtTimestamp)
+	}
+
+	env.SetTTL(time.Hour, now)
+
+	for _, extension := range []time.Duration{0, -time.Minute} {
+		err = env.ExtendTTL(extension, now)
+
+		if !errors.As(err, &ErrInvalidEnvTTLExtension{}) {
+			t.Fatalf("expected invalid extension error, got '%+v'", err)
+		}
+	}
+
+	if env.ExpiresAtTimestamp != now.Add(time.Hour).Unix() {
+		t.Fatalf(
+			"expected env to expire at '%d', got '%d'",
+			now.Add(time.Hour).Unix(),
+			env.ExpiresAtTimestamp,
+		)
+	}
+}
+
+func TestBuildEnvNameSlugWithVariant(t *testing.T) {
+	envName := BuildEnvNameFromResolvedRepo(ResolvedEnvRepository{
+		Owner:   "yolo-sh",
+		Name:    "yolo",
+		Variant: "feature/x",
+	})
+
+	if envName != "yolo-sh/yolo@feature/x" {
+		t.Fatalf("expected env name to equal 'yolo-sh/yolo@feature/x', got '%s'", envName)
+	}
+
+	envNameSlug := BuildEnvNameSlug(envName)
+
+	if envNameSlug != "yolo-sh-yolo-feature-x" {
+		t.Fatalf("expected env name slug to equal 'yolo-sh-yolo-feature-x', got '%s'", envNameSlug)
+	}
+}
+
+func TestBuildEnvNameFromResolvedRepoWithHosts(t *testing.T) {
+	testCases := []struct {
+		test                string
+		host                string
+		expectedEnvName     string
+		expectedEnvNameSlug string
+	}{
+		{
+			test:                "without host",
+			host:                "",
+			expectedEnvName:     "yolo-sh/yolo",
+			expectedEnvNameSlug: "yolo-sh-yolo",
+		},
+
+		{
+			test:                "with default host",
+			host:                DefaultEnvRepositoryHost,
+			expectedEnvName:     "yolo-sh/yolo",
+			expectedEnvNameSlug: "yolo-sh-yolo",
+		},
+
+		{
+			test:                "with other host",
+			host:                "gitlab.com",
+			expectedEnvName:     "gitlab.com/yolo-sh/yolo",
+			expectedEnvNameSlug: "gitlab-com-yolo-sh-yolo",
+		},
+
+		{
+			test:                "with self-managed host",
+			host:                "git.example.com",
+			expectedEnvName:     "git.example.com/yolo-sh/yolo",
+			expectedEnvNameSlug: "git-example-com-yolo-sh-yolo",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			envName := BuildEnvNameFromResolvedRepo(ResolvedEnvRepository{
+				Host:  tc.host,
+				Owner: "yolo-sh",
+				Name:  "yolo",
+			})
+
+			if envName != tc.expectedEnvName {
+				t.Fatalf("expected env name to equal '%s', got '%s'", tc.expectedEnvName, envName)
+			}
+
+			envNameSlug := BuildEnvNameSlug(envName)
+
+			if envNameSlug != tc.expectedEnvNameSlug {
+				t.Fatalf("expected env name slug to equal '%s', got '%s'", tc.expectedEnvNameSlug, envNameSlug)
+			}
+		})
+	}
+}
+
+func TestGetEnvWithSameRepositoryOnOtherHost(t *testing.T) {
+	config := NewConfig()
+	cluster := NewCluster(DefaultClusterName, "t2.medium", true)
+
+	config.SetCluster(cluster)
+
+	gitHubRepository := ResolvedEnvRepository{
+		Host:  "github.com",
+		Owner: "yolo-sh",
+		Name:  "yolo",
+	}
+
+	config.SetEnv(cluster.Name, NewEnv(
+		BuildEnvNameFromResolvedRepo(gitHubRepository),
+		"t2.medium",
+		gitHubRepository,
+	))
+
+	gitLabEnvName := BuildEnvNameFromResolvedRepo(ResolvedEnvRepository{
+		Host:  "gitlab.com",
+		Owner: "yolo-sh",
+		Name:  "yolo",
+	})
+
+	_, err := config.GetEnv(cluster.Name, gitLabEnvName)
+
+	if !errors.As(err, &ErrEnvNotExists{}) {
+		t.Fatalf("expected env not exists error, got '%+v'", err)
+	}
+
+	err = config.CheckEnvNameSlugUniqueness(cluster.Name, gitLabEnvName)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestCheckEnvNameSlugUniqueness(t *testing.T) {
+	config := NewConfig()
+	cluster := NewCluster(DefaultClusterName, "t2.medium", true)
+
+	config.SetCluster(cluster)
+	config.SetEnv(cluster.Name, NewEnv(
+		"yolo-sh/yolo-feature-x",
+		"t2.medium",
+		ResolvedEnvRepository{},
+	))
+
+	err := config.CheckEnvNameSlugUniqueness(cluster.Name, "yolo-sh/yolo@feature-x")
+
+	if !errors.As(err, &ErrEnvNameSlugConflict{}) {
+		t.Fatalf("expected env name slug conflict error, got '%+v'", err)
+	}
+
+	err = config.CheckEnvNameSlugUniqueness(cluster.Name, "yolo-sh/yolo-feature-x")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestEnvSSHKeyStrategyDefaults(t *testing.T) {
+	env := &Env{}
+
+	if env.GetSSHKeyStrategy() != EnvSSHKeyStrategyUserKey {
+		t.Fatalf("expected legacy env to use a user key, got '%s'", env.GetSSHKeyStrategy())
+	}
+
+	if env.GetRepositoryAccessMode() != EnvRepositoryAccessModeReadWrite {
+		t.Fatalf("expected legacy env to have read-write access, got '%s'", env.GetRepositoryAccessMode())
+	}
+
+	err := CheckEnvSSHKeyStrategyValidity("team_key")
+
+	if _, ok := err.(ErrInvalidEnvSSHKeyStrategy); !ok {
+		t.Fatalf("expected invalid strategy error, got '%+v'", err)
+	}
+
+	err = CheckEnvRepositoryAccessModeValidity(EnvRepositoryAccessModeReadOnly)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestGetSSHKeyPairName(t *testing.T) {
+	testCases := []struct {
+		test              string
+		algorithm         EnvSSHKeyPairAlgorithm
+		expectedName      string
+		expectedAlgorithm EnvSSHKeyPairAlgorithm
+	}{
+		{
+			test:              "with legacy env",
+			algorithm:         "",
+			expectedName:      "yolo-yolo-sh-yolo-key-pair",
+			expectedAlgorithm: EnvSSHKeyPairAlgorithmRSA,
+		},
+
+		{
+			test:              "with ed25519 key pair",
+			algorithm:         EnvSSHKeyPairAlgorithmEd25519,
+			expectedName:      "yolo-yolo-sh-yolo-ed25519-key-pair",
+			expectedAlgorithm: EnvSSHKeyPairAlgorithmEd25519,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{
+				Name:                "yolo-sh-yolo",
+				SSHKeyPairAlgorithm: tc.algorithm,
+			}
+
+			if env.GetSSHKeyPairName() != tc.expectedName {
+				t.Fatalf(
+					"expected key pair name to equal '%s', got '%s'",
+					tc.expectedName,
+					env.GetSSHKeyPairName(),
+				)
+			}
+
+			if env.GetSSHKeyPairAlgorithm() != tc.expectedAlgorithm {
+				t.Fatalf(
+					"expected key pair algorithm to equal '%s', got '%s'",
+					tc.expectedAlgorithm,
+					env.GetSSHKeyPairAlgorithm(),
+				)
+			}
+
+			if !IsYoloVCSKey(VCSKey{Title: env.GetSSHKeyPairName()}) {
+				t.Fatalf("expected key pair name to be recognized as a Yolo key")
+			}
+		})
+	}
+}
+
+func TestCheckEnvSSHKeyPairSizeValidity(t *testing.T) {
+	err := CheckEnvSSHKeyPairSizeValidity(EnvSSHKeyPairAlgorithmRSA, 3072)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = CheckEnvSSHKeyPairSizeValidity(EnvSSHKeyPairAlgorithmEd25519, 4096)
+
+	if !errors.As(err, &ErrInvalidEnvSSHKeyPairSize{}) {
+		t.Fatalf("expected invalid size error, got '%+v'", err)
+	}
+}
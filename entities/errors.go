@@ -6,3 +6,20 @@ var (
 	ErrYoloNotInstalled      = errors.New("ErrYoloNotInstalled")
 	ErrUninstallExistingEnvs = errors.New("ErrUninstallExistingEnvs")
 )
+
+// ErrRemoveNotConfirmed is returned when a removal is requested
+// without confirmation function nor force flag.
+type ErrRemoveNotConfirmed struct{}
+
+func (ErrRemoveNotConfirmed) Error() string {
+	return "ErrRemoveNotConfirmed"
+}
+
+// ErrRemoveOrphanedResourcesWithoutConfig is returned when
+// the orphaned resources are removed while Yolo is not installed
+// (all the resources are orphaned) without explicit consent.
+type ErrRemoveOrphanedResourcesWithoutConfig struct{}
+
+func (ErrRemoveOrphanedResourcesWithoutConfig) Error() string {
+	return "ErrRemoveOrphanedResourcesWithoutConfig"
+}
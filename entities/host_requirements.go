@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HostRequirements represents the minimum resources
+// needed by an env. Zero values mean no requirement.
+type HostRequirements struct {
+	CPUs         int   `json:"cpus"`
+	MemoryBytes  int64 `json:"memory_bytes"`
+	StorageBytes int64 `json:"storage_bytes"`
+	GPU          bool  `json:"gpu"`
+}
+
+func (h HostRequirements) IsEmpty() bool {
+	return h == HostRequirements{}
+}
+
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	// Longest suffixes first
+	{suffix: "tb", multiplier: 1024 * 1024 * 1024 * 1024},
+	{suffix: "gb", multiplier: 1024 * 1024 * 1024},
+	{suffix: "mb", multiplier: 1024 * 1024},
+	{suffix: "kb", multiplier: 1024},
+	{suffix: "b", multiplier: 1},
+}
+
+// ParseByteSize parses sizes like "4gb" or "512MB"
+// (binary units are used: 1gb = 1024mb).
+func ParseByteSize(size string) (int64, bool) {
+	sanitizedSize := strings.ToLower(strings.TrimSpace(size))
+
+	for _, unit := range byteSizeUnits {
+		if !strings.HasSuffix(sanitizedSize, unit.suffix) {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(
+			strings.TrimSpace(strings.TrimSuffix(sanitizedSize, unit.suffix)),
+			64,
+		)
+
+		if err != nil || value < 0 {
+			return 0, false
+		}
+
+		return int64(value * float64(unit.multiplier)), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+// IdlePolicy defines when an env is considered as idle
+// and could be stopped automatically.
+//
+// The policy is disabled when "IdleTimeoutSeconds" is zero.
+type IdlePolicy struct {
+	IdleTimeoutSeconds  int64   `json:"idle_timeout_seconds"`
+	CPUThresholdPercent float64 `json:"cpu_threshold_percent"`
+}
+
+func (p IdlePolicy) IsEnabled() bool {
+	return p.IdleTimeoutSeconds > 0
+}
+
+func (p IdlePolicy) IsEnvIdle(report IdleReport, now time.Time) bool {
+	if !p.IsEnabled() {
+		return false
+	}
+
+	if report.ActiveSSHSessions > 0 ||
+		report.CPUUsagePercent > p.CPUThresholdPercent {
+
+		return false
+	}
+
+	// Unknown activity (eg: freshly started env)
+	if report.LastActivityTimestamp <= 0 {
+		return false
+	}
+
+	idleDuration := now.Sub(time.Unix(report.LastActivityTimestamp, 0))
+
+	return idleDuration >= time.Duration(p.IdleTimeoutSeconds)*time.Second
+}
+
+// IdleReport represents the activity of an env.
+// It could be filled by the cloud providers
+// or by the agent running in the env.
+type IdleReport struct {
+	ActiveSSHSessions int     `json:"active_ssh_sessions"`
+	CPUUsagePercent   float64 `json:"cpu_usage_percent"`
+	// Zero means that no activity is known yet
+	// and that the env is not considered as idle
+	LastActivityTimestamp int64 `json:"last_activity_timestamp"`
+}
+
+// IdleReporter returns the activity report of the passed env.
+// A nil report means that no activity is known and that the
+// env must not be considered as idle.
+type IdleReporter interface {
+	Report(
+		cloudService CloudService,
+		config *Config,
+		cluster *Cluster,
+		env *Env,
+	) (*IdleReport, error)
+}
+
+// GetIdlePolicy returns the idle policy of the env
+// or the one of its cluster when not overridden.
+func (e *Env) GetIdlePolicy(cluster *Cluster) *IdlePolicy {
+	if e.IdlePolicy != nil {
+		return e.IdlePolicy
+	}
+
+	return cluster.IdlePolicy
+}
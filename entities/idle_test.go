@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdlePolicyIsEnvIdle(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	policy := IdlePolicy{
+		IdleTimeoutSeconds:  1800,
+		CPUThresholdPercent: 5,
+	}
+
+	testCases := []struct {
+		test         string
+		policy       IdlePolicy
+		report       IdleReport
+		expectedIdle bool
+	}{
+		{
+			test:   "with idle env",
+			policy: policy,
+			report: IdleReport{
+				CPUUsagePercent:       2,
+				LastActivityTimestamp: now.Add(-time.Hour).Unix(),
+			},
+			expectedIdle: true,
+		},
+
+		{
+			test:   "with active SSH session",
+			policy: policy,
+			report: IdleReport{
+				ActiveSSHSessions:     1,
+				CPUUsagePercent:       2,
+				LastActivityTimestamp: now.Add(-time.Hour).Unix(),
+			},
+			expectedIdle: false,
+		},
+
+		{
+			test:   "with high CPU usage",
+			policy: policy,
+			report: IdleReport{
+				CPUUsagePercent:       50,
+				LastActivityTimestamp: now.Add(-time.Hour).Unix(),
+			},
+			expectedIdle: false,
+		},
+
+		{
+			test:   "with recent activity",
+			policy: policy,
+			report: IdleReport{
+				LastActivityTimestamp: now.Add(-time.Minute).Unix(),
+			},
+			expectedIdle: false,
+		},
+
+		{
+			test:   "without known activity",
+			policy: policy,
+			report: IdleReport{
+				CPUUsagePercent: 2,
+			},
+			expectedIdle: false,
+		},
+
+		{
+			test:   "with disabled policy",
+			policy: IdlePolicy{},
+			report: IdleReport{
+				LastActivityTimestamp: now.Add(-time.Hour).Unix(),
+			},
+			expectedIdle: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			idle := tc.policy.IsEnvIdle(tc.report, now)
+
+			if idle != tc.expectedIdle {
+				t.Fatalf("expected idle to equal '%t', got '%t'", tc.expectedIdle, idle)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package entities
+
+import "strings"
+
+// InstanceTypeRule recommends resources and tooling for
+// the repositories matching its languages and size.
+type InstanceTypeRule struct {
+	Name string `json:"name"`
+	// Empty means that the rule matches all languages
+	Languages []string `json:"languages"`
+	// Minimum share of one of the rule languages in the repository
+	MinLanguagePercentage  float64          `json:"min_language_percentage"`
+	MinRepositorySizeBytes int64            `json:"min_repository_size_bytes"`
+	HostRequirements       HostRequirements `json:"host_requirements"`
+	// Takes precedence over the host requirements when set
+	InstanceType string   `json:"instance_type"`
+	Tooling      []string `json:"tooling"`
+}
+
+const gigabyte = 1024 * 1024 * 1024
+
+// DefaultInstanceTypeRules are used when the
+// config doesn't define its own instance type rules.
+var DefaultInstanceTypeRules = []InstanceTypeRule{
+	{
+		Name:                  "go",
+		Languages:             []string{"Go"},
+		MinLanguagePercentage: 10,
+		Tooling:               []string{"go"},
+	},
+
+	{
+		Name:                  "node",
+		Languages:             []string{"JavaScript", "TypeScript"},
+		MinLanguagePercentage: 10,
+		HostRequirements: HostRequirements{
+			MemoryBytes: 4 * gigabyte,
+		},
+		Tooling: []string{"nodejs"},
+	},
+
+	{
+		Name:                  "python",
+		Languages:             []string{"Python", "Jupyter Notebook"},
+		MinLanguagePercentage: 10,
+		Tooling:               []string{"python"},
+	},
+
+	{
+		Name:                  "jvm",
+		Languages:             []string{"Java", "Kotlin", "Scala"},
+		MinLanguagePercentage: 10,
+		HostRequirements: HostRequirements{
+			CPUs:        2,
+			MemoryBytes: 8 * gigabyte,
+		},
+		Tooling: []string{"jdk"},
+	},
+
+	{
+		Name:                  "compiled",
+		Languages:             []string{"Rust", "C++", "C"},
+		MinLanguagePercentage: 10,
+		HostRequirements: HostRequirements{
+			CPUs:        4,
+			MemoryBytes: 8 * gigabyte,
+		},
+		Tooling: []string{"build-essential"},
+	},
+
+	{
+		Name:                  "rust",
+		Languages:             []string{"Rust"},
+		MinLanguagePercentage: 10,
+		Tooling:               []string{"rust"},
+	},
+
+	{
+		Name:                   "large-repository",
+		MinRepositorySizeBytes: 1 * gigabyte,
+		HostRequirements: HostRequirements{
+			StorageBytes: 64 * gigabyte,
+		},
+	},
+}
+
+// InstanceTypeRecommendation represents the merged
+// recommendations of all the matching rules.
+type InstanceTypeRecommendation struct {
+	// Set when a matching rule has an explicit instance type
+	InstanceType     string           `json:"instance_type"`
+	HostRequirements HostRequirements `json:"host_requirements"`
+	Tooling          []string         `json:"tooling"`
+	MatchedRules     []string         `json:"matched_rules"`
+}
+
+func (r InstanceTypeRule) Matches(repository ResolvedEnvRepository) bool {
+	if repository.SizeBytes < r.MinRepositorySizeBytes {
+		return false
+	}
+
+	if len(r.Languages) == 0 {
+		return true
+	}
+
+	for _, language := range repository.Languages {
+		if language.Percentage < r.MinLanguagePercentage {
+			continue
+		}
+
+		for _, ruleLanguage := range r.Languages {
+			if strings.EqualFold(ruleLanguage, language.Name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// RecommendInstanceType applies the passed rules to the repository.
+//
+// The host requirements of all the matching rules are merged
+// (the highest value wins) whereas the explicit instance type
+// of the first matching rule takes precedence.
+func RecommendInstanceType(
+	repository ResolvedEnvRepository,
+	rules []InstanceTypeRule,
+) InstanceTypeRecommendation {
+
+	recommendation := InstanceTypeRecommendation{
+		Tooling:      []string{},
+		MatchedRules: []string{},
+	}
+
+	addedTooling := map[string]bool{}
+
+	for _, rule := range rules {
+		if !rule.Matches(repository) {
+			continue
+		}
+
+		recommendation.MatchedRules = append(recommendation.MatchedRules, rule.Name)
+
+		if len(recommendation.InstanceType) == 0 {
+			recommendation.InstanceType = rule.InstanceType
+		}
+
+		recommendation.HostRequirements = mergeHostRequirements(
+			recommendation.HostRequirements,
+			rule.HostRequirements,
+		)
+
+		for _, tool := range rule.Tooling {
+			if addedTooling[tool] {
+				continue
+			}
+
+			addedTooling[tool] = true
+			recommendation.Tooling = append(recommendation.Tooling, tool)
+		}
+	}
+
+	return recommendation
+}
+
+func mergeHostRequirements(a, b HostRequirements) HostRequirements {
+	merged := a
+
+	if b.CPUs > merged.CPUs {
+		merged.CPUs = b.CPUs
+	}
+
+	if b.MemoryBytes > merged.MemoryBytes {
+		merged.MemoryBytes = b.MemoryBytes
+	}
+
+	if b.StorageBytes > merged.StorageBytes {
+		merged.StorageBytes = b.StorageBytes
+	}
+
+	merged.GPU = merged.GPU || b.GPU
+
+	return merged
+}
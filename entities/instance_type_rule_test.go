@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRepositoryLanguagesFromBytes(t *testing.T) {
+	languages := NewRepositoryLanguagesFromBytes(map[string]int64{
+		"Shell":      250,
+		"Go":         1500,
+		"Dockerfile": 250,
+		"Makefile":   0,
+	})
+
+	expectedLanguages := []RepositoryLanguage{
+		{Name: "Go", Bytes: 1500, Percentage: 75},
+		{Name: "Dockerfile", Bytes: 250, Percentage: 12.5},
+		{Name: "Shell", Bytes: 250, Percentage: 12.5},
+		{Name: "Makefile", Bytes: 0, Percentage: 0},
+	}
+
+	if !reflect.DeepEqual(expectedLanguages, languages) {
+		t.Fatalf(
+			"expected languages to equal '%+v', got '%+v'",
+			expectedLanguages,
+			languages,
+		)
+	}
+}
+
+func TestRecommendInstanceType(t *testing.T) {
+	rules := []InstanceTypeRule{
+		{
+			Name:                  "go",
+			Languages:             []string{"go"},
+			MinLanguagePercentage: 10,
+			Tooling:               []string{"go"},
+		},
+
+		{
+			Name:                  "node",
+			Languages:             []string{"JavaScript", "TypeScript"},
+			MinLanguagePercentage: 10,
+			HostRequirements: HostRequirements{
+				CPUs:        2,
+				MemoryBytes: 4 * gigabyte,
+			},
+			Tooling: []string{"nodejs", "go"},
+		},
+
+		{
+			Name:                   "large-repository",
+			MinRepositorySizeBytes: 1 * gigabyte,
+			HostRequirements: HostRequirements{
+				MemoryBytes:  2 * gigabyte,
+				StorageBytes: 64 * gigabyte,
+			},
+			InstanceType: "t2.xlarge",
+		},
+	}
+
+	testCases := []struct {
+		test                   string
+		repository             ResolvedEnvRepository
+		expectedRecommendation InstanceTypeRecommendation
+	}{
+		{
+			test: "with no matching rules",
+			repository: ResolvedEnvRepository{
+				Languages: []RepositoryLanguage{
+					{Name: "Go", Percentage: 5},
+					{Name: "Ruby", Percentage: 95},
+				},
+			},
+			expectedRecommendation: InstanceTypeRecommendation{
+				Tooling:      []string{},
+				MatchedRules: []string{},
+			},
+		},
+
+		{
+			test: "with multiple matching languages",
+			repository: ResolvedEnvRepository{
+				Languages: []RepositoryLanguage{
+					{Name: "Go", Percentage: 60},
+					{Name: "TypeScript", Percentage: 40},
+				},
+			},
+			expectedRecommendation: InstanceTypeRecommendation{
+				HostRequirements: HostRequirements{
+					CPUs:        2,
+					MemoryBytes: 4 * gigabyte,
+				},
+				Tooling:      []string{"go", "nodejs"},
+				MatchedRules: []string{"go", "node"},
+			},
+		},
+
+		{
+			test: "with large repository",
+			repository: ResolvedEnvRepository{
+				Languages: []RepositoryLanguage{
+					{Name: "JavaScript", Percentage: 100},
+				},
+				SizeBytes: 2 * gigabyte,
+			},
+			expectedRecommendation: InstanceTypeRecommendation{
+				InstanceType: "t2.xlarge",
+				HostRequirements: HostRequirements{
+					CPUs:         2,
+					MemoryBytes:  4 * gigabyte,
+					StorageBytes: 64 * gigabyte,
+				},
+				Tooling:      []string{"nodejs", "go"},
+				MatchedRules: []string{"node", "large-repository"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			recommendation := RecommendInstanceType(tc.repository, rules)
+
+			if !reflect.DeepEqual(tc.expectedRecommendation, recommendation) {
+				t.Fatalf(
+					"expected recommendation to equal '%+v', got '%+v'",
+					tc.expectedRecommendation,
+					recommendation,
+				)
+			}
+		})
+	}
+}
+
+func TestConfigGetInstanceTypeRules(t *testing.T) {
+	config := NewConfig()
+
+	if !reflect.DeepEqual(DefaultInstanceTypeRules, config.GetInstanceTypeRules()) {
+		t.Fatalf("expected default rules to be used when not set")
+	}
+
+	config.InstanceTypeRules = []InstanceTypeRule{}
+
+	if len(config.GetInstanceTypeRules()) != 0 {
+		t.Fatalf("expected user rules to override the default ones")
+	}
+}
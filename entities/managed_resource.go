@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// ManagedResourceGracePeriod is the duration during which the
+// new resources are never considered as orphaned given that the
+// resources of an env are created before the env is saved in the
+// config (eg: by an "init" running in another terminal).
+const ManagedResourceGracePeriod = time.Hour
+
+// ManagedResource represents a cloud resource
+// tagged as managed by Yolo.
+//
+// "ClusterID" and "EnvID" are empty for the
+// resources that don't belong to a cluster
+// or to an env (eg: the Yolo config storage).
+type ManagedResource struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	ClusterID string `json:"cluster_id"`
+	EnvID     string `json:"env_id"`
+	// Zero when the cloud provider doesn't return the creation time
+	CreatedAtTimestamp int64 `json:"created_at_timestamp"`
+}
+
+func (m ManagedResource) IsInGracePeriod(now time.Time) bool {
+	return m.CreatedAtTimestamp > 0 &&
+		now.Sub(time.Unix(m.CreatedAtTimestamp, 0)) < ManagedResourceGracePeriod
+}
+
+// FindOrphanedResources returns the managed resources
+// that belong to a cluster or to an env that
+// is not referenced in the config anymore.
+//
+// The resources in grace period are skipped as well as all the
+// env resources when an env is in creating state given that
+// another env may be created concurrently.
+//
+// The env resources are returned before the cluster ones
+// so that they are removed first.
+func (c *Config) FindOrphanedResources(
+	resources []ManagedResource,
+	now time.Time,
+) []ManagedResource {
+
+	clusterIDs := map[string]bool{}
+	envIDs := map[string]bool{}
+	creatingEnvs := false
+
+	for _, cluster := range c.Clusters {
+		clusterIDs[cluster.ID] = true
+
+		for _, env := range cluster.Envs {
+			envIDs[env.ID] = true
+
+			if env.Status == EnvStatusCreating {
+				creatingEnvs = true
+			}
+		}
+	}
+
+	orphanedResources := []ManagedResource{}
+
+	for _, resource := range resources {
+		if resource.IsInGracePeriod(now) {
+			continue
+		}
+
+		orphanedEnvResource := len(resource.EnvID) > 0 &&
+			!envIDs[resource.EnvID] && !creatingEnvs
+
+		orphanedClusterResource := len(resource.EnvID) == 0 &&
+			len(resource.ClusterID) > 0 &&
+			!clusterIDs[resource.ClusterID]
+
+		if orphanedEnvResource || orphanedClusterResource {
+			orphanedResources = append(orphanedResources, resource)
+		}
+	}
+
+	sort.SliceStable(orphanedResources, func(i, j int) bool {
+		return len(orphanedResources[i].EnvID) > 0 &&
+			len(orphanedResources[j].EnvID) == 0
+	})
+
+	return orphanedResources
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindOrphanedResources(t *testing.T) {
+	now := time.Now()
+	oldTimestamp := now.Add(-2 * ManagedResourceGracePeriod).Unix()
+
+	config := NewConfig()
+	cluster := NewCluster(DefaultClusterName, "t2.medium", true)
+	config.SetCluster(cluster)
+
+	env := NewEnv("yolo-sh/yolo", "t2.medium", ResolvedEnvRepository{})
+	env.Status = EnvStatusCreated
+	config.SetEnv(cluster.Name, env)
+
+	resources := []ManagedResource{
+		{ID: "config", Type: "bucket"},
+		{ID: "cluster", Type: "vpc", ClusterID: cluster.ID, CreatedAtTimestamp: oldTimestamp},
+		{ID: "env", Type: "instance", ClusterID: cluster.ID, EnvID: env.ID, CreatedAtTimestamp: oldTimestamp},
+		{ID: "orphaned-cluster", Type: "vpc", ClusterID: "removed-cluster", CreatedAtTimestamp: oldTimestamp},
+		{ID: "orphaned-env", Type: "instance", ClusterID: cluster.ID, EnvID: "removed-env"},
+		// Env created concurrently and not saved in the config yet
+		{ID: "new-env", Type: "instance", ClusterID: cluster.ID, EnvID: "new-env", CreatedAtTimestamp: now.Unix()},
+	}
+
+	orphanedResources := config.FindOrphanedResources(resources, now)
+
+	// Env resources are removed first
+	expectedOrphanedResources := []ManagedResource{
+		resources[4],
+		resources[3],
+	}
+
+	if !reflect.DeepEqual(expectedOrphanedResources, orphanedResources) {
+		t.Fatalf(
+			"expected orphaned resources to equal '%+v', got '%+v'",
+			expectedOrphanedResources,
+			orphanedResources,
+		)
+	}
+
+	// Env resources are skipped while an env is created
+	env.Status = EnvStatusCreating
+
+	orphanedResources = config.FindOrphanedResources(resources, now)
+	expectedOrphanedResources = []ManagedResource{
+		resources[3],
+	}
+
+	if !reflect.DeepEqual(expectedOrphanedResources, orphanedResources) {
+		t.Fatalf(
+			"expected orphaned resources to equal '%+v', got '%+v'",
+			expectedOrphanedResources,
+			orphanedResources,
+		)
+	}
+}
@@ -0,0 +1,66 @@
+package entities
+
+type CloudServiceOperationType string
+
+const (
+	CloudServiceOperationCreateYoloConfigStorage CloudServiceOperationType = "create_yolo_config_storage"
+	CloudServiceOperationRemoveYoloConfigStorage CloudServiceOperationType = "remove_yolo_config_storage"
+	CloudServiceOperationCreateCluster           CloudServiceOperationType = "create_cluster"
+	CloudServiceOperationRemoveCluster           CloudServiceOperationType = "remove_cluster"
+	CloudServiceOperationCreateEnv               CloudServiceOperationType = "create_env"
+	CloudServiceOperationRemoveEnv               CloudServiceOperationType = "remove_env"
+	CloudServiceOperationStopEnv                 CloudServiceOperationType = "stop_env"
+	CloudServiceOperationStartEnv                CloudServiceOperationType = "start_env"
+	CloudServiceOperationUpdateEnvSSHKeyPair     CloudServiceOperationType = "update_env_ssh_key_pair"
+	CloudServiceOperationOpenPort                CloudServiceOperationType = "open_port"
+	CloudServiceOperationClosePort               CloudServiceOperationType = "close_port"
+	CloudServiceOperationOpenPorts               CloudServiceOperationType = "open_ports"
+	CloudServiceOperationClosePorts              CloudServiceOperationType = "close_ports"
+	CloudServiceOperationRemoveManagedResource   CloudServiceOperationType = "remove_managed_resource"
+)
+
+// CloudServiceOperation represents a mutating call to a cloud service
+// that needs to be planned instead of executed (see "PlanOperation").
+//
+// Only the fields related to the operation type are set
+// (eg: "Port" is only set for the "open_port" and "close_port" types
+// whereas "Ports" is only set for the "open_ports" and "close_ports" ones).
+type CloudServiceOperation struct {
+	Type            CloudServiceOperationType
+	Cluster         *Cluster
+	Env             *Env
+	Port            PortSpec
+	Ports           []PortSpec
+	ManagedResource *ManagedResource
+}
+
+type PlannedOperationAction string
+
+const (
+	PlannedOperationActionCreate PlannedOperationAction = "create"
+	PlannedOperationActionUpdate PlannedOperationAction = "update"
+	PlannedOperationActionRemove PlannedOperationAction = "remove"
+)
+
+// PlannedOperation represents an operation that
+// a cloud service would run on a cloud resource.
+type PlannedOperation struct {
+	Action       PlannedOperationAction `json:"action"`
+	ResourceType string                 `json:"resource_type"`
+	ResourceName string                 `json:"resource_name"`
+	Description  string                 `json:"description"`
+}
+
+type Plan struct {
+	Operations []PlannedOperation `json:"operations"`
+}
+
+func NewPlan() *Plan {
+	return &Plan{
+		Operations: []PlannedOperation{},
+	}
+}
+
+func (p *Plan) AddOperations(operations ...PlannedOperation) {
+	p.Operations = append(p.Operations, operations...)
+}
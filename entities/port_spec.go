@@ -0,0 +1,244 @@
+package entities
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type PortProtocol string
+
+const (
+	PortProtocolTCP PortProtocol = "tcp"
+	PortProtocolUDP PortProtocol = "udp"
+)
+
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+const (
+	portSpecProtocolSeparator    = "/"
+	portSpecRangeSeparator       = "-"
+	portSpecSourceCIDRsSeparator = "@"
+)
+
+// PortSpec represents a port (or a range of ports)
+// opened in an env, optionally to some sources only.
+type PortSpec struct {
+	Protocol PortProtocol `json:"protocol"`
+	FromPort int          `json:"from_port"`
+	// Equal to "FromPort" for single ports
+	ToPort int `json:"to_port"`
+	// Empty means that the ports are opened to the world
+	SourceCIDRs []string `json:"source_cidrs"`
+}
+
+// NewTCPPortSpec returns the spec of a
+// single TCP port opened to the world.
+func NewTCPPortSpec(port int) PortSpec {
+	return PortSpec{
+		Protocol:    PortProtocolTCP,
+		FromPort:    port,
+		ToPort:      port,
+		SourceCIDRs: []string{},
+	}
+}
+
+// ParsePortSpec parses port specs formatted like
+// "<port>[-<port>][/<protocol>][@<cidr>[,<cidr>...]]".
+//
+// eg: "8080", "8000-8010/udp", "8080@203.0.113.7" ("my IP only").
+//
+// The protocol defaults to TCP and the IP addresses
+// without prefix length are restricted to themselves.
+func ParsePortSpec(portSpec string) (PortSpec, error) {
+	errInvalidPort := ErrInvalidPort{
+		InvalidPort: portSpec,
+	}
+
+	parsedPortSpec := PortSpec{
+		Protocol:    PortProtocolTCP,
+		SourceCIDRs: []string{},
+	}
+
+	ports := strings.TrimSpace(portSpec)
+
+	if sourceCIDRsIndex := strings.Index(ports, portSpecSourceCIDRsSeparator); sourceCIDRsIndex != -1 {
+		sourceCIDRs := ports[sourceCIDRsIndex+1:]
+		ports = ports[:sourceCIDRsIndex]
+
+		for _, sourceCIDR := range strings.Split(sourceCIDRs, ",") {
+			normalizedSourceCIDR, err := normalizeSourceCIDR(
+				strings.TrimSpace(sourceCIDR),
+			)
+
+			if err != nil {
+				return PortSpec{}, err
+			}
+
+			parsedPortSpec.SourceCIDRs = append(
+				parsedPortSpec.SourceCIDRs,
+				normalizedSourceCIDR,
+			)
+		}
+	}
+
+	if protocolIndex := strings.Index(ports, portSpecProtocolSeparator); protocolIndex != -1 {
+		parsedPortSpec.Protocol = PortProtocol(
+			strings.ToLower(ports[protocolIndex+1:]),
+		)
+		ports = ports[:protocolIndex]
+	}
+
+	portRange := strings.SplitN(ports, portSpecRangeSeparator, 2)
+
+	fromPort, err := strconv.Atoi(portRange[0])
+
+	if err != nil {
+		return PortSpec{}, errInvalidPort
+	}
+
+	toPort := fromPort
+
+	if len(portRange) == 2 {
+		toPort, err = strconv.Atoi(portRange[1])
+
+		if err != nil {
+			return PortSpec{}, errInvalidPort
+		}
+	}
+
+	parsedPortSpec.FromPort = fromPort
+	parsedPortSpec.ToPort = toPort
+
+	err = parsedPortSpec.Validate([]string{})
+
+	if err != nil {
+		return PortSpec{}, err
+	}
+
+	parsedPortSpec.normalizeSourceCIDRs()
+
+	return parsedPortSpec, nil
+}
+
+func normalizeSourceCIDR(sourceCIDR string) (string, error) {
+	if !strings.Contains(sourceCIDR, "/") {
+		ip := net.ParseIP(sourceCIDR)
+
+		if ip == nil {
+			return "", ErrInvalidPortSourceCIDR{
+				SourceCIDR: sourceCIDR,
+			}
+		}
+
+		if ip.To4() != nil {
+			return ip.String() + "/32", nil
+		}
+
+		return ip.String() + "/128", nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(sourceCIDR)
+
+	if err != nil {
+		return "", ErrInvalidPortSourceCIDR{
+			SourceCIDR: sourceCIDR,
+		}
+	}
+
+	return ipNet.String(), nil
+}
+
+func (p *PortSpec) normalizeSourceCIDRs() {
+	uniqueSourceCIDRs := map[string]bool{}
+	sourceCIDRs := []string{}
+
+	for _, sourceCIDR := range p.SourceCIDRs {
+		if uniqueSourceCIDRs[sourceCIDR] {
+			continue
+		}
+
+		uniqueSourceCIDRs[sourceCIDR] = true
+		sourceCIDRs = append(sourceCIDRs, sourceCIDR)
+	}
+
+	sort.Strings(sourceCIDRs)
+	p.SourceCIDRs = sourceCIDRs
+}
+
+// Validate checks the protocol, the ports and the source CIDRs of the spec.
+// The ranges that contain a reserved port are invalid.
+func (p PortSpec) Validate(reservedPorts []string) error {
+	if p.Protocol != PortProtocolTCP && p.Protocol != PortProtocolUDP {
+		return ErrInvalidPortProtocol{
+			Protocol: p.Protocol,
+		}
+	}
+
+	if p.FromPort < minPort || p.ToPort > maxPort || p.FromPort > p.ToPort {
+		return ErrInvalidPort{
+			InvalidPort: p.String(),
+		}
+	}
+
+	for _, reservedPort := range reservedPorts {
+		reservedPortAsInt, err := strconv.Atoi(reservedPort)
+
+		if err == nil && p.ContainsPort(reservedPortAsInt) {
+			return ErrReservedPort{
+				ReservedPort: reservedPort,
+			}
+		}
+	}
+
+	for _, sourceCIDR := range p.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(sourceCIDR); err != nil {
+			return ErrInvalidPortSourceCIDR{
+				SourceCIDR: sourceCIDR,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p PortSpec) ContainsPort(port int) bool {
+	return port >= p.FromPort && port <= p.ToPort
+}
+
+func (p PortSpec) IsRange() bool {
+	return p.FromPort != p.ToPort
+}
+
+func (p PortSpec) IsOpenedToTheWorld() bool {
+	return len(p.SourceCIDRs) == 0
+}
+
+// String returns the spec in the format parsed by "ParsePortSpec".
+//
+// The TCP protocol is omitted so that single TCP ports opened
+// to the world are formatted as their number (eg: "8080").
+func (p PortSpec) String() string {
+	portSpec := strconv.Itoa(p.FromPort)
+
+	if p.IsRange() {
+		portSpec += portSpecRangeSeparator + strconv.Itoa(p.ToPort)
+	}
+
+	if p.Protocol != PortProtocolTCP {
+		portSpec += portSpecProtocolSeparator + string(p.Protocol)
+	}
+
+	if !p.IsOpenedToTheWorld() {
+		sourceCIDRs := append([]string{}, p.SourceCIDRs...)
+		sort.Strings(sourceCIDRs)
+
+		portSpec += portSpecSourceCIDRsSeparator + strings.Join(sourceCIDRs, ",")
+	}
+
+	return portSpec
+}
@@ -0,0 +1,196 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseValidPortSpecs(t *testing.T) {
+	testCases := []struct {
+		test             string
+		portSpec         string
+		expectedPortSpec PortSpec
+		expectedString   string
+	}{
+		{
+			test:             "with single port",
+			portSpec:         "8080",
+			expectedPortSpec: NewTCPPortSpec(8080),
+			expectedString:   "8080",
+		},
+
+		{
+			test:     "with range and protocol",
+			portSpec: "8000-8010/UDP",
+			expectedPortSpec: PortSpec{
+				Protocol:    PortProtocolUDP,
+				FromPort:    8000,
+				ToPort:      8010,
+				SourceCIDRs: []string{},
+			},
+			expectedString: "8000-8010/udp",
+		},
+
+		{
+			test:     "with explicit TCP protocol and source IP addresses",
+			portSpec: "8080/tcp@203.0.113.7,10.0.0.0/8,203.0.113.7",
+			expectedPortSpec: PortSpec{
+				Protocol:    PortProtocolTCP,
+				FromPort:    8080,
+				ToPort:      8080,
+				SourceCIDRs: []string{"10.0.0.0/8", "203.0.113.7/32"},
+			},
+			expectedString: "8080@10.0.0.0/8,203.0.113.7/32",
+		},
+
+		{
+			test:     "with IPv6 source",
+			portSpec: "443@2001:db8::1",
+			expectedPortSpec: PortSpec{
+				Protocol:    PortProtocolTCP,
+				FromPort:    443,
+				ToPort:      443,
+				SourceCIDRs: []string{"2001:db8::1/128"},
+			},
+			expectedString: "443@2001:db8::1/128",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			portSpec, err := ParsePortSpec(tc.portSpec)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedPortSpec, portSpec) {
+				t.Fatalf(
+					"expected port spec to equal '%+v', got '%+v'",
+					tc.expectedPortSpec,
+					portSpec,
+				)
+			}
+
+			if portSpec.String() != tc.expectedString {
+				t.Fatalf(
+					"expected port spec string to equal '%s', got '%s'",
+					tc.expectedString,
+					portSpec.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestParseInvalidPortSpecs(t *testing.T) {
+	testCases := []struct {
+		test          string
+		portSpec      string
+		expectedError error
+	}{
+		{
+			test:          "with invalid port",
+			portSpec:      "invalid_port",
+			expectedError: ErrInvalidPort{},
+		},
+
+		{
+			test:          "with reversed range",
+			portSpec:      "8010-8000",
+			expectedError: ErrInvalidPort{},
+		},
+
+		{
+			test:          "with greater than maximum port",
+			portSpec:      "65530-65536",
+			expectedError: ErrInvalidPort{},
+		},
+
+		{
+			test:          "with invalid protocol",
+			portSpec:      "8080/icmp",
+			expectedError: ErrInvalidPortProtocol{},
+		},
+
+		{
+			test:          "with invalid source CIDR",
+			portSpec:      "8080@10.0.0.0/33",
+			expectedError: ErrInvalidPortSourceCIDR{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParsePortSpec(tc.portSpec)
+
+			if err == nil || reflect.TypeOf(err) != reflect.TypeOf(tc.expectedError) {
+				t.Fatalf("expected '%T' error, got '%+v'", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidatePortSpecWithReservedPort(t *testing.T) {
+	portSpec, err := ParsePortSpec("2000-3000")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	err = portSpec.Validate([]string{"2200"})
+
+	if !errors.As(err, &ErrReservedPort{}) {
+		t.Fatalf("expected reserved port error, got '%+v'", err)
+	}
+}
+
+func TestUnmarshalLegacyEnvOpenedPorts(t *testing.T) {
+	legacyEnvJSON := `{"name": "yolo-sh/yolo", "opened_ports": {"8080": true, "3000": false}}`
+
+	var env Env
+	err := json.Unmarshal([]byte(legacyEnvJSON), &env)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedOpenedPorts := map[string]EnvOpenedPort{
+		"8080": {PortSpec: NewTCPPortSpec(8080)},
+	}
+
+	if env.Name != "yolo-sh/yolo" {
+		t.Fatalf("expected env name to equal 'yolo-sh/yolo', got '%s'", env.Name)
+	}
+
+	if !reflect.DeepEqual(expectedOpenedPorts, env.OpenedPorts) {
+		t.Fatalf(
+			"expected opened ports to equal '%+v', got '%+v'",
+			expectedOpenedPorts,
+			env.OpenedPorts,
+		)
+	}
+
+	envJSON, err := json.Marshal(&env)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	var migratedEnv Env
+	err = json.Unmarshal(envJSON, &migratedEnv)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(expectedOpenedPorts, migratedEnv.OpenedPorts) {
+		t.Fatalf(
+			"expected opened ports to equal '%+v', got '%+v'",
+			expectedOpenedPorts,
+			migratedEnv.OpenedPorts,
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectManifestFilePath is the path of the project
+// manifest, relative to the root of the repository.
+const ProjectManifestFilePath = ".yolo.yml"
+
+var projectManifestEnvVarNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type ProjectManifestHooks struct {
+	// Commands run in the env once created
+	Init []string `yaml:"init" json:"init"`
+}
+
+// ProjectManifest represents the dev environment
+// declared by a repository in its ".yolo.yml" file.
+//
+// All the fields are optional. The CLI inputs
+// take precedence over the manifest values.
+type ProjectManifest struct {
+	InstanceType string `yaml:"instance_type" json:"instance_type"`
+	// Port specs (eg: "8080", "8000-8010/udp", see "ParsePortSpec")
+	Ports   []string             `yaml:"ports" json:"ports"`
+	Hooks   ProjectManifestHooks `yaml:"hooks" json:"hooks"`
+	EnvVars map[string]string    `yaml:"env_vars" json:"env_vars"`
+	// Go duration (eg: "72h")
+	TTL string `yaml:"ttl" json:"ttl"`
+}
+
+func ParseProjectManifest(manifestContent string) (*ProjectManifest, error) {
+	manifest := &ProjectManifest{}
+
+	decoder := yaml.NewDecoder(bytes.NewBufferString(manifestContent))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(manifest)
+
+	// Empty manifests are valid
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ErrInvalidProjectManifest{
+			Reason: err.Error(),
+		}
+	}
+
+	return manifest, nil
+}
+
+func (p *ProjectManifest) Validate(reservedPorts []string) error {
+	for _, port := range p.Ports {
+		portSpec, err := ParsePortSpec(port)
+
+		if err != nil {
+			return err
+		}
+
+		err = portSpec.Validate(reservedPorts)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for envVarName := range p.EnvVars {
+		if !projectManifestEnvVarNameRegexp.MatchString(envVarName) {
+			return ErrInvalidProjectManifest{
+				Reason: "invalid environment variable name \"" + envVarName + "\"",
+			}
+		}
+	}
+
+	_, err := p.GetTTL()
+
+	return err
+}
+
+// GetTTL returns the parsed TTL.
+// Zero means that no TTL was set.
+func (p *ProjectManifest) GetTTL() (time.Duration, error) {
+	if len(p.TTL) == 0 {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(p.TTL)
+
+	if err != nil || ttl < 0 {
+		return 0, ErrInvalidProjectManifest{
+			Reason: "invalid TTL \"" + p.TTL + "\"",
+		}
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,9 @@
+package entities
+
+type ErrInvalidProjectManifest struct {
+	Reason string
+}
+
+func (ErrInvalidProjectManifest) Error() string {
+	return "ErrInvalidProjectManifest"
+}
@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseProjectManifest(t *testing.T) {
+	manifestContent := `
+instance_type: t2.large
+ports:
+  - 3000
+  - "8080"
+hooks:
+  init:
+    - npm install
+env_vars:
+  NODE_ENV: development
+ttl: 72h
+`
+
+	manifest, err := ParseProjectManifest(manifestContent)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedManifest := &ProjectManifest{
+		InstanceType: "t2.large",
+		Ports:        []string{"3000", "8080"},
+		Hooks: ProjectManifestHooks{
+			Init: []string{"npm install"},
+		},
+		EnvVars: map[string]string{
+			"NODE_ENV": "development",
+		},
+		TTL: "72h",
+	}
+
+	if !reflect.DeepEqual(expectedManifest, manifest) {
+		t.Fatalf(
+			"expected manifest to equal '%+v', got '%+v'",
+			expectedManifest,
+			manifest,
+		)
+	}
+
+	ttl, err := manifest.GetTTL()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if ttl != 72*time.Hour {
+		t.Fatalf("expected TTL to equal '72h', got '%s'", ttl)
+	}
+}
+
+func TestParseEmptyProjectManifest(t *testing.T) {
+	manifest, err := ParseProjectManifest("")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual(&ProjectManifest{}, manifest) {
+		t.Fatalf("expected empty manifest, got '%+v'", manifest)
+	}
+}
+
+func TestParseInvalidProjectManifest(t *testing.T) {
+	testCases := []struct {
+		test            string
+		manifestContent string
+	}{
+		{
+			test:            "with unknown field",
+			manifestContent: "instance_typ: t2.large",
+		},
+
+		{
+			test:            "with invalid YAML",
+			manifestContent: "ports: [3000",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseProjectManifest(tc.manifestContent)
+
+			if _, ok := err.(ErrInvalidProjectManifest); !ok {
+				t.Fatalf("expected invalid manifest error, got '%+v'", err)
+			}
+		})
+	}
+}
+
+func TestProjectManifestValidate(t *testing.T) {
+	testCases := []struct {
+		test          string
+		manifest      ProjectManifest
+		expectedError error
+	}{
+		{
+			test: "with valid manifest",
+			manifest: ProjectManifest{
+				Ports:   []string{"3000"},
+				EnvVars: map[string]string{"NODE_ENV": "development"},
+				TTL:     "1h30m",
+			},
+			expectedError: nil,
+		},
+
+		{
+			test: "with invalid port",
+			manifest: ProjectManifest{
+				Ports: []string{"70000"},
+			},
+			expectedError: ErrInvalidPort{InvalidPort: "70000"},
+		},
+
+		{
+			test: "with reserved port",
+			manifest: ProjectManifest{
+				Ports: []string{"22"},
+			},
+			expectedError: ErrReservedPort{ReservedPort: "22"},
+		},
+
+		{
+			test: "with invalid env var name",
+			manifest: ProjectManifest{
+				EnvVars: map[string]string{"NODE-ENV": "development"},
+			},
+			expectedError: ErrInvalidProjectManifest{
+				Reason: "invalid environment variable name \"NODE-ENV\"",
+			},
+		},
+
+		{
+			test: "with negative TTL",
+			manifest: ProjectManifest{
+				TTL: "-1h",
+			},
+			expectedError: ErrInvalidProjectManifest{
+				Reason: "invalid TTL \"-1h\"",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			err := tc.manifest.Validate([]string{"22"})
+
+			if !reflect.DeepEqual(tc.expectedError, err) {
+				t.Fatalf(
+					"expected error to equal '%+v', got '%+v'",
+					tc.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package entities
+
+import "sort"
+
+// RepositoryLanguage represents the share of a language in a repository.
+//
+// "Bytes" may be zero for the VCS providers
+// that only return percentages (eg: GitLab).
+type RepositoryLanguage struct {
+	Name       string  `json:"name"`
+	Bytes      int64   `json:"bytes"`
+	Percentage float64 `json:"percentage"`
+}
+
+// NewRepositoryLanguagesFromBytes builds the language breakdown
+// from the number of bytes written in each language.
+func NewRepositoryLanguagesFromBytes(
+	languagesBytes map[string]int64,
+) []RepositoryLanguage {
+
+	totalBytes := int64(0)
+
+	for _, bytes := range languagesBytes {
+		totalBytes += bytes
+	}
+
+	languages := []RepositoryLanguage{}
+
+	for name, bytes := range languagesBytes {
+		percentage := float64(0)
+
+		if totalBytes > 0 {
+			percentage = float64(bytes) * 100 / float64(totalBytes)
+		}
+
+		languages = append(languages, RepositoryLanguage{
+			Name:       name,
+			Bytes:      bytes,
+			Percentage: percentage,
+		})
+	}
+
+	SortRepositoryLanguages(languages)
+
+	return languages
+}
+
+// NewRepositoryLanguagesFromPercentages builds the language breakdown
+// from the percentage of the repository written in each language.
+func NewRepositoryLanguagesFromPercentages(
+	languagesPercentages map[string]float64,
+) []RepositoryLanguage {
+
+	languages := []RepositoryLanguage{}
+
+	for name, percentage := range languagesPercentages {
+		languages = append(languages, RepositoryLanguage{
+			Name:       name,
+			Percentage: percentage,
+		})
+	}
+
+	SortRepositoryLanguages(languages)
+
+	return languages
+}
+
+// SortRepositoryLanguages sorts the passed languages from the most
+// used to the least used. Ties are sorted by name to get a stable order.
+func SortRepositoryLanguages(languages []RepositoryLanguage) {
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Percentage != languages[j].Percentage {
+			return languages[i].Percentage > languages[j].Percentage
+		}
+
+		return languages[i].Name < languages[j].Name
+	})
+}
+
+// RepositoryLanguageNames returns the names
+// of the passed languages, in the same order.
+func RepositoryLanguageNames(languages []RepositoryLanguage) []string {
+	names := []string{}
+
+	for _, language := range languages {
+		names = append(names, language.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,82 @@
+package entities
+
+import "time"
+
+// Schedule defines when an env should be running using two
+// cron expressions evaluated in the passed timezone.
+//
+// Example (office hours): start "0 9 * * 1-5", stop "0 19 * * 1-5".
+type Schedule struct {
+	// IANA timezone (eg: "Europe/Paris"). Defaults to UTC.
+	Timezone  string `json:"timezone"`
+	StartCron string `json:"start_cron"`
+	StopCron  string `json:"stop_cron"`
+}
+
+type ScheduleState struct {
+	ShouldBeRunning bool
+	// Zero when the schedule never transitions again
+	NextTransitionAt time.Time
+}
+
+func (s Schedule) Evaluate(at time.Time) (*ScheduleState, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+
+	if err != nil {
+		return nil, ErrInvalidScheduleTimezone{
+			Timezone: s.Timezone,
+		}
+	}
+
+	startCron, err := ParseCronExpression(s.StartCron)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stopCron, err := ParseCronExpression(s.StopCron)
+
+	if err != nil {
+		return nil, err
+	}
+
+	localAt := at.In(loc)
+
+	lastStart, lastStartFound := startCron.Previous(localAt)
+	lastStop, lastStopFound := stopCron.Previous(localAt)
+
+	shouldBeRunning := lastStartFound &&
+		(!lastStopFound || lastStart.After(lastStop))
+
+	nextTransitionCron := startCron
+
+	if shouldBeRunning {
+		nextTransitionCron = stopCron
+	}
+
+	nextTransitionAt, _ := nextTransitionCron.Next(localAt)
+
+	return &ScheduleState{
+		ShouldBeRunning:  shouldBeRunning,
+		NextTransitionAt: nextTransitionAt,
+	}, nil
+}
+
+// GetSchedule returns the schedule of the env
+// or the one of its cluster when not overridden.
+func (e *Env) GetSchedule(cluster *Cluster) *Schedule {
+	if e.Schedule != nil {
+		return e.Schedule
+	}
+
+	return cluster.Schedule
+}
+
+// ShouldBeStartedBySchedule returns whether the env could be started
+// by its schedule. Only the envs stopped by their schedule are
+// started so that the expired and idle envs are not restarted.
+func (e *Env) ShouldBeStartedBySchedule(at time.Time) bool {
+	return e.Status == EnvStatusStopped &&
+		e.StopReason == EnvStopReasonSchedule &&
+		!e.HasExpired(at)
+}
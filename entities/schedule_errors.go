@@ -0,0 +1,28 @@
+package entities
+
+type ErrInvalidCronExpression struct {
+	Expression string
+}
+
+func (ErrInvalidCronExpression) Error() string {
+	return "ErrInvalidCronExpression"
+}
+
+type ErrInvalidScheduleTimezone struct {
+	Timezone string
+}
+
+func (ErrInvalidScheduleTimezone) Error() string {
+	return "ErrInvalidScheduleTimezone"
+}
+
+// ErrScheduleEnvs is returned when some of the scheduled envs
+// could not be started or stopped. The error of each env is
+// reported along with it.
+type ErrScheduleEnvs struct {
+	FailedEnvsCount int
+}
+
+func (ErrScheduleEnvs) Error() string {
+	return "ErrScheduleEnvs"
+}
@@ -0,0 +1,232 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseInvalidCronExpressions(t *testing.T) {
+	testCases := []struct {
+		test       string
+		expression string
+	}{
+		{
+			test:       "with missing fields",
+			expression: "0 9 * *",
+		},
+
+		{
+			test:       "with out of range value",
+			expression: "60 9 * * *",
+		},
+
+		{
+			test:       "with invalid range",
+			expression: "0 19-9 * * *",
+		},
+
+		{
+			test:       "with invalid step",
+			expression: "*/0 * * * *",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseCronExpression(tc.expression)
+
+			if !errors.As(err, &ErrInvalidCronExpression{}) {
+				t.Fatalf("expected invalid cron expression error, got '%+v'", err)
+			}
+		})
+	}
+}
+
+func TestCronExpressionNextAndPrevious(t *testing.T) {
+	cron, err := ParseCronExpression("30 9 * * 1-5")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	// Saturday
+	at := time.Date(2022, time.June, 4, 12, 0, 0, 0, time.UTC)
+
+	expectedNext := time.Date(2022, time.June, 6, 9, 30, 0, 0, time.UTC)
+	next, found := cron.Next(at)
+
+	if !found || !next.Equal(expectedNext) {
+		t.Fatalf("expected next to equal '%s', got '%s'", expectedNext, next)
+	}
+
+	expectedPrevious := time.Date(2022, time.June, 3, 9, 30, 0, 0, time.UTC)
+	previous, found := cron.Previous(at)
+
+	if !found || !previous.Equal(expectedPrevious) {
+		t.Fatalf("expected previous to equal '%s', got '%s'", expectedPrevious, previous)
+	}
+}
+
+func TestScheduleEvaluate(t *testing.T) {
+	schedule := Schedule{
+		Timezone:  "Europe/Paris",
+		StartCron: "0 9 * * 1-5",
+		StopCron:  "0 19 * * 1-5",
+	}
+
+	paris, err := time.LoadLocation("Europe/Paris")
+
+	if err != nil {
+		t.Skipf("timezone database not available: %s", err)
+	}
+
+	testCases := []struct {
+		test                     string
+		at                       time.Time
+		expectedShouldBeRunning  bool
+		expectedNextTransitionAt time.Time
+	}{
+		{
+			test:                     "during office hours",
+			at:                       time.Date(2022, time.June, 1, 10, 0, 0, 0, paris),
+			expectedShouldBeRunning:  true,
+			expectedNextTransitionAt: time.Date(2022, time.June, 1, 19, 0, 0, 0, paris),
+		},
+
+		{
+			test:                     "during the night",
+			at:                       time.Date(2022, time.June, 1, 22, 0, 0, 0, paris),
+			expectedShouldBeRunning:  false,
+			expectedNextTransitionAt: time.Date(2022, time.June, 2, 9, 0, 0, 0, paris),
+		},
+
+		{
+			test:                     "during the weekend",
+			at:                       time.Date(2022, time.June, 4, 10, 0, 0, 0, paris),
+			expectedShouldBeRunning:  false,
+			expectedNextTransitionAt: time.Date(2022, time.June, 6, 9, 0, 0, 0, paris),
+		},
+
+		{
+			test:                     "with time in another timezone",
+			at:                       time.Date(2022, time.June, 1, 7, 30, 0, 0, time.UTC),
+			expectedShouldBeRunning:  true,
+			expectedNextTransitionAt: time.Date(2022, time.June, 1, 19, 0, 0, 0, paris),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			state, err := schedule.Evaluate(tc.at)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if state.ShouldBeRunning != tc.expectedShouldBeRunning {
+				t.Fatalf(
+					"expected should be running to equal '%t', got '%t'",
+					tc.expectedShouldBeRunning,
+					state.ShouldBeRunning,
+				)
+			}
+
+			if !state.NextTransitionAt.Equal(tc.expectedNextTransitionAt) {
+				t.Fatalf(
+					"expected next transition to equal '%s', got '%s'",
+					tc.expectedNextTransitionAt,
+					state.NextTransitionAt,
+				)
+			}
+		})
+	}
+}
+
+func TestEnvShouldBeStartedBySchedule(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	testCases := []struct {
+		test               string
+		status             EnvStatus
+		stopReason         EnvStopReason
+		expiresAtTimestamp int64
+		expectedStart      bool
+	}{
+		{
+			test:          "with env stopped by schedule",
+			status:        EnvStatusStopped,
+			stopReason:    EnvStopReasonSchedule,
+			expectedStart: true,
+		},
+
+		{
+			test:               "with env stopped by schedule and not expired",
+			status:             EnvStatusStopped,
+			stopReason:         EnvStopReasonSchedule,
+			expiresAtTimestamp: now.Add(time.Hour).Unix(),
+			expectedStart:      true,
+		},
+
+		{
+			test:               "with expired env stopped by schedule",
+			status:             EnvStatusStopped,
+			stopReason:         EnvStopReasonSchedule,
+			expiresAtTimestamp: now.Add(-time.Hour).Unix(),
+			expectedStart:      false,
+		},
+
+		{
+			test:          "with expired env",
+			status:        EnvStatusStopped,
+			stopReason:    EnvStopReasonExpired,
+			expectedStart: false,
+		},
+
+		{
+			test:          "with idle env",
+			status:        EnvStatusStopped,
+			stopReason:    EnvStopReasonIdle,
+			expectedStart: false,
+		},
+
+		{
+			test:          "with env stopped by user",
+			status:        EnvStatusStopped,
+			stopReason:    EnvStopReasonUser,
+			expectedStart: false,
+		},
+
+		{
+			test:          "with env stopped without reason",
+			status:        EnvStatusStopped,
+			expectedStart: false,
+		},
+
+		{
+			test:          "with running env",
+			status:        EnvStatusCreated,
+			expectedStart: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			env := &Env{
+				Status:             tc.status,
+				StopReason:         tc.stopReason,
+				ExpiresAtTimestamp: tc.expiresAtTimestamp,
+			}
+
+			shouldBeStarted := env.ShouldBeStartedBySchedule(now)
+
+			if shouldBeStarted != tc.expectedStart {
+				t.Fatalf(
+					"expected should be started to equal '%t', got '%t'",
+					tc.expectedStart,
+					shouldBeStarted,
+				)
+			}
+		})
+	}
+}
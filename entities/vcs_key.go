@@ -0,0 +1,45 @@
+package entities
+
+import "strings"
+
+const (
+	yoloVCSKeyTitlePrefix = "yolo-"
+	yoloVCSKeyTitleSuffix = "-key-pair"
+)
+
+// IsYoloVCSKey returns whether the passed key was registered
+// by Yolo (ie: titled like "yolo-<env_name_slug>-key-pair").
+//
+// The keys without title are never considered as registered by Yolo.
+func IsYoloVCSKey(key VCSKey) bool {
+	return len(key.Title) > len(yoloVCSKeyTitlePrefix+yoloVCSKeyTitleSuffix) &&
+		strings.HasPrefix(key.Title, yoloVCSKeyTitlePrefix) &&
+		strings.HasSuffix(key.Title, yoloVCSKeyTitleSuffix)
+}
+
+// FindUnknownVCSKeys returns the keys registered by
+// Yolo that are not referenced by any env in the config.
+//
+// These keys are not necessarily orphaned: they may belong to the envs
+// of another Yolo installation of the same user (eg: on another cloud
+// provider) given that the key titles are not scoped by installation.
+// As a result, they must only be removed after confirmation.
+func (c *Config) FindUnknownVCSKeys(keys []VCSKey) []VCSKey {
+	referencedKeyTitles := map[string]bool{}
+
+	for _, cluster := range c.Clusters {
+		for _, env := range cluster.Envs {
+			referencedKeyTitles[env.GetSSHKeyPairName()] = true
+		}
+	}
+
+	unknownKeys := []VCSKey{}
+
+	for _, key := range keys {
+		if IsYoloVCSKey(key) && !referencedKeyTitles[key.Title] {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+
+	return unknownKeys
+}
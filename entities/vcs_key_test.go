@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindUnknownVCSKeys(t *testing.T) {
+	config := NewConfig()
+	cluster := NewCluster(DefaultClusterName, "t2.medium", true)
+
+	config.SetCluster(cluster)
+
+	env := NewEnv("yolo-sh/yolo", "t2.medium", ResolvedEnvRepository{})
+	config.SetEnv(cluster.Name, env)
+
+	// Env of another installation sharing the same VCS account
+	otherEnv := NewEnv("yolo-sh/api", "t2.medium", ResolvedEnvRepository{})
+
+	keys := []VCSKey{
+		{ID: "1", Title: env.GetSSHKeyPairName()},
+		{ID: "2", Title: otherEnv.GetSSHKeyPairName()},
+		{ID: "3", Title: "my-laptop"},
+		{ID: "4", Title: ""},
+		{ID: "5", Title: "yolo--key-pair"},
+	}
+
+	unknownKeys := config.FindUnknownVCSKeys(keys)
+	expectedUnknownKeys := []VCSKey{
+		{ID: "2", Title: otherEnv.GetSSHKeyPairName()},
+	}
+
+	if !reflect.DeepEqual(expectedUnknownKeys, unknownKeys) {
+		t.Fatalf(
+			"expected unknown keys to equal '%+v', got '%+v'",
+			expectedUnknownKeys,
+			unknownKeys,
+		)
+	}
+}
+
+func TestFindUnknownVCSKeysWithoutEnvs(t *testing.T) {
+	config := NewConfig()
+
+	keys := []VCSKey{
+		{ID: "1", Title: "yolo-yolo-sh-yolo-key-pair"},
+		{ID: "2", Title: "my-laptop"},
+	}
+
+	unknownKeys := config.FindUnknownVCSKeys(keys)
+	expectedUnknownKeys := []VCSKey{
+		{ID: "1", Title: "yolo-yolo-sh-yolo-key-pair"},
+	}
+
+	if !reflect.DeepEqual(expectedUnknownKeys, unknownKeys) {
+		t.Fatalf(
+			"expected unknown keys to equal '%+v', got '%+v'",
+			expectedUnknownKeys,
+			unknownKeys,
+		)
+	}
+}
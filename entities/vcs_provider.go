@@ -0,0 +1,94 @@
+package entities
+
+// VCSKey represents an SSH or GPG key
+// registered on a VCS provider account.
+type VCSKey struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Only relevant for deploy keys
+	ReadOnly bool `json:"read_only"`
+}
+
+// VCSProvider represents a repository backend (eg: GitHub, GitLab...).
+//
+// The access token is passed to each method given that
+// it may differ between users of the same provider.
+type VCSProvider interface {
+	Host() string
+
+	// An empty username means that the access token is not tied to
+	// a user (eg: GitHub App installation tokens)
+	GetAuthenticatedUsername(accessToken string) (string, error)
+
+	ResolveRepository(
+		accessToken string,
+		repositoryName string,
+		defaultRepositoryOwner string,
+	) (*ResolvedEnvRepository, error)
+
+	// An empty ref means that the default branch is resolved.
+	// Like in Git, tags take precedence over branches with the same name.
+	ResolveRepositoryRef(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+		ref string,
+	) (*ResolvedEnvRepositoryRef, error)
+
+	// An empty ref means that the file is read from the default branch
+	GetFileContentFromRepository(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+		filePath string,
+		ref string,
+	) (string, error)
+
+	GetLanguagesUsedInRepository(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+	) ([]string, error)
+
+	GetLanguagesBreakdown(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+	) ([]RepositoryLanguage, error)
+
+	// User keys may not be supported with some authentication
+	// methods (eg: GitHub App installation tokens)
+	SupportsUserKeys() bool
+	RegisterSSHKey(accessToken, keyTitle, publicKeyContent string) (*VCSKey, error)
+	ListRegisteredSSHKeys(accessToken string) ([]VCSKey, error)
+	UnregisterSSHKey(accessToken, keyID string) error
+
+	// Deploy keys only grant access to one repository
+	SupportsReadWriteDeployKeys() bool
+	RegisterDeployKey(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+		keyTitle string,
+		publicKeyContent string,
+		readOnly bool,
+	) (*VCSKey, error)
+	ListRegisteredDeployKeys(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+	) ([]VCSKey, error)
+	UnregisterDeployKey(
+		accessToken string,
+		repositoryOwner string,
+		repositoryName string,
+		keyID string,
+	) error
+
+	// The title may be ignored by the providers that don't name GPG keys
+	RegisterGPGKey(accessToken, keyTitle, publicKeyContent string) (*VCSKey, error)
+	ListRegisteredGPGKeys(accessToken string) ([]VCSKey, error)
+	UnregisterGPGKey(accessToken, keyID string) error
+
+	IsNotFoundError(err error) bool
+}
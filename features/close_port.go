@@ -10,7 +10,8 @@ import (
 
 type ClosePortInput struct {
 	ResolvedRepository entities.ResolvedEnvRepository
-	PortToClose        string
+	PortToClose        entities.PortSpec
+	PlanMode           bool
 }
 
 type ClosePortOutput struct {
@@ -22,8 +23,10 @@ type ClosePortOutput struct {
 type ClosePortOutputContent struct {
 	Cluster           *entities.Cluster
 	Env               *entities.Env
-	PortClosed        string
+	PortClosed        entities.PortSpec
 	PortAlreadyClosed bool
+	// Set only in plan mode
+	Plan *entities.Plan
 }
 
 type ClosePortOutputHandler interface {
@@ -66,7 +69,7 @@ func (o ClosePortFeature) Execute(input ClosePortInput) error {
 	o.stepper.StartTemporaryStep(
 		fmt.Sprintf(
 			"Closing port \"%s\"",
-			input.PortToClose,
+			input.PortToClose.String(),
 		),
 	)
 
@@ -76,6 +79,13 @@ func (o ClosePortFeature) Execute(input ClosePortInput) error {
 		return handleError(err)
 	}
 
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
 	yoloConfig, err := cloudService.LookupYoloConfig(
 		o.stepper,
 	)
@@ -109,7 +119,7 @@ func (o ClosePortFeature) Execute(input ClosePortInput) error {
 		})
 	}
 
-	portAlreadyClosed := !env.OpenedPorts[input.PortToClose]
+	portAlreadyClosed := !env.IsPortOpened(input.PortToClose)
 
 	if !portAlreadyClosed {
 		err = actions.ClosePort(
@@ -133,6 +143,7 @@ func (o ClosePortFeature) Execute(input ClosePortInput) error {
 			Env:               env,
 			PortClosed:        input.PortToClose,
 			PortAlreadyClosed: portAlreadyClosed,
+			Plan:              plan,
 		},
 	})
 }
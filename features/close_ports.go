@@ -0,0 +1,184 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type ClosePortsInput struct {
+	ResolvedRepository entities.ResolvedEnvRepository
+	PortsToClose       []entities.PortSpec
+	PlanMode           bool
+	ReservedPorts      []string
+}
+
+type ClosePortsOutput struct {
+	// Set along with "Content" when the ports failed to close.
+	// The batch is rolled back so the ports are usually all failed.
+	Error   error
+	Content *ClosePortsOutputContent
+	Stepper stepper.Stepper
+}
+
+type ClosePortsOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	// In the order of the ports to close (without duplicates)
+	Results []PortResult
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type ClosePortsOutputHandler interface {
+	HandleOutput(ClosePortsOutput) error
+}
+
+type ClosePortsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ClosePortsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewClosePortsFeature(
+	stepper stepper.Stepper,
+	outputHandler ClosePortsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ClosePortsFeature {
+
+	return ClosePortsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (o ClosePortsFeature) Execute(input ClosePortsInput) error {
+	handleError := func(err error) error {
+		o.outputHandler.HandleOutput(ClosePortsOutput{
+			Stepper: o.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	// All the ports are validated before any
+	// of them is closed to keep the batch atomic
+	for _, portToClose := range input.PortsToClose {
+		err := portToClose.Validate(input.ReservedPorts)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	portsToClose := dedupePortSpecs(input.PortsToClose)
+
+	envName := entities.BuildEnvNameFromResolvedRepo(
+		input.ResolvedRepository,
+	)
+
+	o.stepper.StartTemporaryStep(
+		fmt.Sprintf("Closing %d port(s)", len(portsToClose)),
+	)
+
+	cloudService, err := o.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		o.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := yoloConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := yoloConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrClosePortRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrClosePortCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	alreadyClosedPorts := map[string]bool{}
+	portsNotClosed := []entities.PortSpec{}
+
+	for _, portToClose := range portsToClose {
+		if !env.IsPortOpened(portToClose) {
+			alreadyClosedPorts[portToClose.String()] = true
+			continue
+		}
+
+		portsNotClosed = append(portsNotClosed, portToClose)
+	}
+
+	var closePortsErr error
+
+	if len(portsNotClosed) > 0 {
+		closePortsErr = actions.ClosePorts(
+			o.stepper,
+			cloudService,
+			yoloConfig,
+			cluster,
+			env,
+			portsNotClosed,
+		)
+	}
+
+	isPortClosed := func(portSpec entities.PortSpec) bool {
+		return !env.IsPortOpened(portSpec)
+	}
+
+	results := buildPortResults(
+		portsToClose,
+		alreadyClosedPorts,
+		isPortClosed,
+		PortResultStatusAlreadyClosed,
+		PortResultStatusClosed,
+		closePortsErr,
+	)
+
+	o.outputHandler.HandleOutput(ClosePortsOutput{
+		Stepper: o.stepper,
+		Error:   closePortsErr,
+		Content: &ClosePortsOutputContent{
+			Cluster: cluster,
+			Env:     env,
+			Results: results,
+			Plan:    plan,
+		},
+	})
+
+	return closePortsErr
+}
@@ -0,0 +1,163 @@
+package features
+
+import (
+	"sort"
+	"time"
+
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type CostInput struct {
+	// Overrides the estimates returned by the cloud service
+	PricingTable entities.PricingTable
+}
+
+type CostOutput struct {
+	Error   error
+	Content *CostOutputContent
+	Stepper stepper.Stepper
+}
+
+type CostOutputContent struct {
+	Report *entities.CostReport
+}
+
+type CostOutputHandler interface {
+	HandleOutput(CostOutput) error
+}
+
+type CostFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       CostOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewCostFeature(
+	stepper stepper.Stepper,
+	outputHandler CostOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) CostFeature {
+
+	return CostFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (c CostFeature) Execute(input CostInput) error {
+	handleError := func(err error) error {
+		c.outputHandler.HandleOutput(CostOutput{
+			Stepper: c.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	c.stepper.StartTemporaryStep("Estimating the cost of your environments")
+
+	cloudService, err := c.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		c.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	now := time.Now()
+	report := entities.NewCostReport()
+
+	for _, clusterName := range sortedClusterNames(yoloConfig) {
+		cluster := yoloConfig.Clusters[clusterName]
+		clusterCost := entities.NewClusterCost(cluster)
+
+		// Cloud service estimates are cached per cluster
+		// given that they may depend on the cluster region
+		estimates := map[string]entities.CostEstimate{}
+
+		for instanceType, estimate := range input.PricingTable {
+			estimates[instanceType] = estimate
+		}
+
+		for _, envName := range sortedEnvNames(cluster) {
+			env := cluster.Envs[envName]
+			estimate, estimateFound := estimates[env.InstanceType]
+
+			if !estimateFound {
+				cloudServiceEstimate, err := cloudService.EstimateCost(
+					c.stepper,
+					env.InstanceType,
+					cluster,
+				)
+
+				if err != nil {
+					return handleError(err)
+				}
+
+				// The pricing table was looked up first so
+				// no estimate is available for this instance type
+				if cloudServiceEstimate == nil {
+					return handleError(entities.ErrCostEstimateNotFound{
+						InstanceType: env.InstanceType,
+					})
+				}
+
+				estimate = *cloudServiceEstimate
+				estimates[env.InstanceType] = estimate
+			}
+
+			err = clusterCost.AddEnvCost(
+				entities.NewEnvCost(env, estimate, now),
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+		}
+
+		err = report.AddClusterCost(*clusterCost)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	return c.outputHandler.HandleOutput(CostOutput{
+		Stepper: c.stepper,
+		Content: &CostOutputContent{
+			Report: report,
+		},
+	})
+}
+
+func sortedClusterNames(yoloConfig *entities.Config) []string {
+	clusterNames := []string{}
+
+	for clusterName := range yoloConfig.Clusters {
+		clusterNames = append(clusterNames, clusterName)
+	}
+
+	sort.Strings(clusterNames)
+
+	return clusterNames
+}
+
+func sortedEnvNames(cluster *entities.Cluster) []string {
+	envNames := []string{}
+
+	for envName := range cluster.Envs {
+		envNames = append(envNames, envName)
+	}
+
+	sort.Strings(envNames)
+
+	return envNames
+}
@@ -0,0 +1,51 @@
+package features
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// loadDevContainer loads the devcontainer configuration
+// from the repository of the passed env, at its pinned ref.
+//
+// A nil configuration is returned when the repository doesn't have one.
+func loadDevContainer(
+	vcsProvider entities.VCSProvider,
+	vcsAccessToken string,
+	resolvedRepository entities.ResolvedEnvRepository,
+	reservedPorts []string,
+) (*entities.DevContainer, error) {
+
+	for _, devContainerFilePath := range entities.DevContainerFilePaths {
+		devContainerContent, err := vcsProvider.GetFileContentFromRepository(
+			vcsAccessToken,
+			resolvedRepository.Owner,
+			resolvedRepository.Name,
+			devContainerFilePath,
+			resolvedRepository.GetPinnedRef(),
+		)
+
+		if vcsProvider.IsNotFoundError(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		devContainer, err := entities.ParseDevContainer(devContainerContent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = devContainer.Validate(reservedPorts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return devContainer, nil
+	}
+
+	return nil, nil
+}
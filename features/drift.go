@@ -0,0 +1,235 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type DriftResolution string
+
+const (
+	// DriftResolutionNone only reports the drift
+	DriftResolutionNone DriftResolution = ""
+	// DriftResolutionAdopt replaces the stored env with the observed state
+	DriftResolutionAdopt DriftResolution = "adopt"
+	// DriftResolutionEnforce re-applies the stored env to the cloud resources
+	DriftResolutionEnforce DriftResolution = "enforce"
+)
+
+type DriftInput struct {
+	ResolvedRepository entities.ResolvedEnvRepository
+	Resolution         DriftResolution
+	PlanMode           bool
+}
+
+type DriftOutput struct {
+	Error   error
+	Content *DriftOutputContent
+	Stepper stepper.Stepper
+}
+
+type DriftOutputContent struct {
+	Cluster       *entities.Cluster
+	Env           *entities.Env
+	ObservedState *entities.EnvObservedState
+	Drift         entities.EnvDrift
+	Resolution    DriftResolution
+	// Drift items that could not be resolved.
+	// Only the opened ports could be re-enforced.
+	UnresolvedDrift []entities.EnvDriftItem
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type DriftOutputHandler interface {
+	HandleOutput(DriftOutput) error
+}
+
+type DriftFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       DriftOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewDriftFeature(
+	stepper stepper.Stepper,
+	outputHandler DriftOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) DriftFeature {
+
+	return DriftFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (d DriftFeature) Execute(input DriftInput) error {
+	handleError := func(err error) error {
+		d.outputHandler.HandleOutput(DriftOutput{
+			Stepper: d.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	if input.Resolution != DriftResolutionNone &&
+		input.Resolution != DriftResolutionAdopt &&
+		input.Resolution != DriftResolutionEnforce {
+
+		return handleError(entities.ErrInvalidDriftResolution{
+			Resolution: string(input.Resolution),
+		})
+	}
+
+	envName := entities.BuildEnvNameFromResolvedRepo(
+		input.ResolvedRepository,
+	)
+
+	d.stepper.StartTemporaryStep(
+		fmt.Sprintf("Detecting drift for the environment \"%s\"", envName),
+	)
+
+	cloudService, err := d.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		d.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := yoloConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := yoloConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrDriftRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrDriftCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	observedState, err := cloudService.DescribeEnv(
+		d.stepper,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	drift := entities.BuildEnvDrift(env, observedState)
+	unresolvedDrift := []entities.EnvDriftItem{}
+
+	if drift.HasDrifted() && input.Resolution == DriftResolutionAdopt {
+		d.stepper.StartTemporaryStep("Adopting the observed state")
+
+		env.AdoptObservedState(observedState)
+
+		// The observed state is only stored in the config
+		if input.PlanMode {
+			plan.AddOperations(entities.PlannedOperation{
+				Action:       entities.PlannedOperationActionUpdate,
+				ResourceType: "yolo_config",
+				ResourceName: envName,
+				Description:  "Adopt the observed state of the environment",
+			})
+		}
+
+		err = actions.UpdateEnvInConfig(
+			d.stepper,
+			cloudService,
+			yoloConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	if drift.HasDrifted() && input.Resolution == DriftResolutionEnforce {
+		d.stepper.StartTemporaryStep("Enforcing the stored state")
+
+		for _, item := range drift.Items {
+			if item.Kind != entities.EnvDriftKindOpenedPort {
+				unresolvedDrift = append(unresolvedDrift, item)
+				continue
+			}
+
+			if len(item.Stored) > 0 { // Stored as opened, observed as closed
+				storedOpenedPort := env.OpenedPorts[item.Stored]
+
+				err = actions.OpenPort(
+					d.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					storedOpenedPort.PortSpec,
+					storedOpenedPort.EnvOpenedPortMetadata,
+				)
+			} else { // Stored as closed, observed as opened
+				err = actions.ClosePort(
+					d.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					observedState.OpenedPorts[item.Observed],
+				)
+			}
+
+			if err != nil {
+				return handleError(err)
+			}
+		}
+	}
+
+	return d.outputHandler.HandleOutput(DriftOutput{
+		Stepper: d.stepper,
+		Content: &DriftOutputContent{
+			Cluster:         cluster,
+			Env:             env,
+			ObservedState:   observedState,
+			Drift:           drift,
+			Resolution:      input.Resolution,
+			UnresolvedDrift: unresolvedDrift,
+			Plan:            plan,
+		},
+	})
+}
@@ -3,12 +3,14 @@ package features
 import (
 	"fmt"
 
+	"github.com/yolo-sh/yolo/actions"
 	"github.com/yolo-sh/yolo/entities"
 	"github.com/yolo-sh/yolo/stepper"
 )
 
 type EditInput struct {
 	ResolvedRepository entities.ResolvedEnvRepository
+	PlanMode           bool
 }
 
 type EditOutput struct {
@@ -20,6 +22,8 @@ type EditOutput struct {
 type EditOutputContent struct {
 	Cluster *entities.Cluster
 	Env     *entities.Env
+	// Set only in plan mode
+	Plan *entities.Plan
 }
 
 type EditOutputHandler interface {
@@ -69,6 +73,13 @@ func (e EditFeature) Execute(input EditInput) error {
 		return handleError(err)
 	}
 
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
 	yoloConfig, err := cloudService.LookupYoloConfig(
 		e.stepper,
 	)
@@ -102,11 +113,21 @@ func (e EditFeature) Execute(input EditInput) error {
 		})
 	}
 
+	if env.Status == entities.EnvStatusStopping ||
+		env.Status == entities.EnvStatusStopped ||
+		env.Status == entities.EnvStatusStarting {
+
+		return handleError(entities.ErrEditStoppedEnv{
+			EnvName: envName,
+		})
+	}
+
 	return e.outputHandler.HandleOutput(EditOutput{
 		Stepper: e.stepper,
 		Content: &EditOutputContent{
 			Cluster: cluster,
 			Env:     env,
+			Plan:    plan,
 		},
 	})
 }
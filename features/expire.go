@@ -0,0 +1,193 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type ExpireInput struct {
+	PreRemoveHook entities.HookRunner
+	// Expired envs are removed unless
+	// "StopExpiredEnvs" is set to true
+	StopExpiredEnvs bool
+	PlanMode        bool
+}
+
+type ExpireOutput struct {
+	// Set along with "Content" when some expired envs failed to be handled
+	Error   error
+	Content *ExpireOutputContent
+	Stepper stepper.Stepper
+}
+
+type ExpiredEnv struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	Stopped bool
+	Removed bool
+	// Errors are reported per env in order to
+	// not block the expiration of the other ones
+	Error error
+}
+
+type ExpireOutputContent struct {
+	ExpiredEnvs []ExpiredEnv
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type ExpireOutputHandler interface {
+	HandleOutput(ExpireOutput) error
+}
+
+type ExpireFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ExpireOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewExpireFeature(
+	stepper stepper.Stepper,
+	outputHandler ExpireOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ExpireFeature {
+
+	return ExpireFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (e ExpireFeature) Execute(input ExpireInput) error {
+	handleError := func(err error) error {
+		e.outputHandler.HandleOutput(ExpireOutput{
+			Stepper: e.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	e.stepper.StartTemporaryStep("Looking for expired environments")
+
+	cloudService, err := e.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		e.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	preRemoveHook := input.PreRemoveHook
+
+	if input.PlanMode {
+		// The pre-remove hook may modify external resources
+		preRemoveHook = nil
+	}
+
+	now := time.Now()
+	expiredEnvs := []ExpiredEnv{}
+	failedEnvsCount := 0
+
+	for _, clusterName := range sortedClusterNames(yoloConfig) {
+		cluster := yoloConfig.Clusters[clusterName]
+
+		for _, envName := range sortedEnvNames(cluster) {
+			env := cluster.Envs[envName]
+
+			if !env.HasExpired(now) {
+				continue
+			}
+
+			// Envs in creating state may be currently
+			// initialized (eg: in another terminal)
+			if env.Status == entities.EnvStatusCreating {
+				continue
+			}
+
+			if input.StopExpiredEnvs && env.Status == entities.EnvStatusStopped {
+				continue
+			}
+
+			expiredEnv := ExpiredEnv{
+				Cluster: cluster,
+				Env:     env,
+			}
+
+			if input.StopExpiredEnvs && env.Status != entities.EnvStatusRemoving {
+				e.stepper.StartTemporaryStep(
+					fmt.Sprintf("Stopping the expired environment \"%s\"", env.Name),
+				)
+
+				expiredEnv.Error = actions.StopEnv(
+					e.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					entities.EnvStopReasonExpired,
+				)
+
+				expiredEnv.Stopped = expiredEnv.Error == nil
+			} else {
+				e.stepper.StartTemporaryStep(
+					fmt.Sprintf("Removing the expired environment \"%s\"", env.Name),
+				)
+
+				expiredEnv.Error = actions.RemoveEnv(
+					e.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					preRemoveHook,
+				)
+
+				expiredEnv.Removed = expiredEnv.Error == nil
+			}
+
+			if expiredEnv.Error != nil {
+				failedEnvsCount++
+			}
+
+			expiredEnvs = append(expiredEnvs, expiredEnv)
+		}
+	}
+
+	var expireErr error
+
+	if failedEnvsCount > 0 {
+		expireErr = entities.ErrExpireEnvs{
+			FailedEnvsCount: failedEnvsCount,
+		}
+	}
+
+	e.outputHandler.HandleOutput(ExpireOutput{
+		Stepper: e.stepper,
+		Error:   expireErr,
+		Content: &ExpireOutputContent{
+			ExpiredEnvs: expiredEnvs,
+			Plan:        plan,
+		},
+	})
+
+	return expireErr
+}
@@ -0,0 +1,167 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type ExpirePortsInput struct {
+	PlanMode bool
+}
+
+type ExpirePortsOutput struct {
+	// Set along with "Content" when some expired ports failed to close
+	Error   error
+	Content *ExpirePortsOutputContent
+	Stepper stepper.Stepper
+}
+
+type ExpiredPort struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	Port    entities.EnvOpenedPort
+	Closed  bool
+	// Errors are reported per port in order to
+	// not block the expiration of the other ones
+	Error error
+}
+
+type ExpirePortsOutputContent struct {
+	ExpiredPorts []ExpiredPort
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type ExpirePortsOutputHandler interface {
+	HandleOutput(ExpirePortsOutput) error
+}
+
+type ExpirePortsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ExpirePortsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewExpirePortsFeature(
+	stepper stepper.Stepper,
+	outputHandler ExpirePortsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ExpirePortsFeature {
+
+	return ExpirePortsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (e ExpirePortsFeature) Execute(input ExpirePortsInput) error {
+	handleError := func(err error) error {
+		e.outputHandler.HandleOutput(ExpirePortsOutput{
+			Stepper: e.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	e.stepper.StartTemporaryStep("Looking for expired ports")
+
+	cloudService, err := e.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		e.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	now := time.Now()
+	expiredPorts := []ExpiredPort{}
+	failedPortsCount := 0
+
+	for _, clusterName := range sortedClusterNames(yoloConfig) {
+		cluster := yoloConfig.Clusters[clusterName]
+
+		for _, envName := range sortedEnvNames(cluster) {
+			env := cluster.Envs[envName]
+
+			// Envs in creating state may be currently
+			// initialized (eg: in another terminal) and
+			// the ports of removing envs are removed with them
+			if env.Status == entities.EnvStatusCreating ||
+				env.Status == entities.EnvStatusRemoving {
+
+				continue
+			}
+
+			for _, openedPort := range env.GetExpiredPorts(now) {
+				e.stepper.StartTemporaryStep(
+					fmt.Sprintf(
+						"Closing the expired port \"%s\" of \"%s\"",
+						openedPort.String(),
+						env.Name,
+					),
+				)
+
+				expiredPort := ExpiredPort{
+					Cluster: cluster,
+					Env:     env,
+					Port:    openedPort,
+				}
+
+				expiredPort.Error = actions.ClosePort(
+					e.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					openedPort.PortSpec,
+				)
+
+				expiredPort.Closed = expiredPort.Error == nil
+
+				if !expiredPort.Closed {
+					failedPortsCount++
+				}
+
+				expiredPorts = append(expiredPorts, expiredPort)
+			}
+		}
+	}
+
+	var expirePortsErr error
+
+	if failedPortsCount > 0 {
+		expirePortsErr = entities.ErrCloseExpiredPorts{
+			FailedPortsCount: failedPortsCount,
+		}
+	}
+
+	e.outputHandler.HandleOutput(ExpirePortsOutput{
+		Stepper: e.stepper,
+		Error:   expirePortsErr,
+		Content: &ExpirePortsOutputContent{
+			ExpiredPorts: expiredPorts,
+			Plan:         plan,
+		},
+	})
+
+	return expirePortsErr
+}
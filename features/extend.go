@@ -0,0 +1,157 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type ExtendInput struct {
+	ResolvedRepository entities.ResolvedEnvRepository
+	Extension          time.Duration
+	PlanMode           bool
+}
+
+type ExtendOutput struct {
+	Error   error
+	Content *ExtendOutputContent
+	Stepper stepper.Stepper
+}
+
+type ExtendOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type ExtendOutputHandler interface {
+	HandleOutput(ExtendOutput) error
+}
+
+type ExtendFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ExtendOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewExtendFeature(
+	stepper stepper.Stepper,
+	outputHandler ExtendOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ExtendFeature {
+
+	return ExtendFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (e ExtendFeature) Execute(input ExtendInput) error {
+	handleError := func(err error) error {
+		e.outputHandler.HandleOutput(ExtendOutput{
+			Stepper: e.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	if input.Extension <= 0 {
+		return handleError(entities.ErrInvalidEnvTTLExtension{
+			Extension: input.Extension,
+		})
+	}
+
+	envName := entities.BuildEnvNameFromResolvedRepo(
+		input.ResolvedRepository,
+	)
+
+	e.stepper.StartTemporaryStep(
+		fmt.Sprintf("Extending the environment for \"%s\"", envName),
+	)
+
+	cloudService, err := e.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		e.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := yoloConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := yoloConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrExtendRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	err = env.ExtendTTL(input.Extension, time.Now())
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// The expiration is only stored in the config
+	if input.PlanMode {
+		plan.AddOperations(entities.PlannedOperation{
+			Action:       entities.PlannedOperationActionUpdate,
+			ResourceType: "yolo_config",
+			ResourceName: envName,
+			Description: fmt.Sprintf(
+				"Extend the environment until %s",
+				time.Unix(env.ExpiresAtTimestamp, 0).Format(time.RFC3339),
+			),
+		})
+	}
+
+	err = actions.UpdateEnvInConfig(
+		e.stepper,
+		cloudService,
+		yoloConfig,
+		cluster,
+		env,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	return e.outputHandler.HandleOutput(ExtendOutput{
+		Stepper: e.stepper,
+		Content: &ExtendOutputContent{
+			Cluster: cluster,
+			Env:     env,
+			Plan:    plan,
+		},
+	})
+}
@@ -0,0 +1,176 @@
+package features
+
+import (
+	"errors"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type GarbageCollectInput struct {
+	// Orphaned resources are only listed (dry run)
+	// unless "RemoveOrphanedResources" is set to true
+	RemoveOrphanedResources bool
+	// The removal is refused unless "ForceRemove" is set
+	// or "ConfirmRemove" returns true
+	ForceRemove   bool
+	ConfirmRemove func([]entities.ManagedResource) (bool, error)
+	// When Yolo is not installed, all the resources are orphaned.
+	// They are only removed when "RemoveWithoutConfig" is set to true.
+	RemoveWithoutConfig bool
+	PlanMode            bool
+}
+
+type GarbageCollectOutput struct {
+	Error   error
+	Content *GarbageCollectOutputContent
+	Stepper stepper.Stepper
+}
+
+type GarbageCollectOutputContent struct {
+	// Also true when the removal was not confirmed
+	DryRun            bool
+	ConfigNotFound    bool
+	OrphanedResources []entities.ManagedResource
+	// Planned for removal in plan mode
+	RemovedResources []entities.ManagedResource
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type GarbageCollectOutputHandler interface {
+	HandleOutput(GarbageCollectOutput) error
+}
+
+type GarbageCollectFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       GarbageCollectOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewGarbageCollectFeature(
+	stepper stepper.Stepper,
+	outputHandler GarbageCollectOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) GarbageCollectFeature {
+
+	return GarbageCollectFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (g GarbageCollectFeature) Execute(input GarbageCollectInput) error {
+	handleError := func(err error) error {
+		g.outputHandler.HandleOutput(GarbageCollectOutput{
+			Stepper: g.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	step := "Looking for orphaned resources"
+	g.stepper.StartTemporaryStep(step)
+
+	cloudService, err := g.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		g.stepper,
+	)
+
+	if err != nil && !errors.Is(err, entities.ErrYoloNotInstalled) {
+		return handleError(err)
+	}
+
+	configNotFound := yoloConfig == nil
+
+	if configNotFound { // Yolo not installed
+		// All the resources that belong to
+		// a cluster or an env are orphaned
+		yoloConfig = entities.NewConfig()
+	}
+
+	managedResources, err := cloudService.ListManagedResources(
+		g.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	orphanedResources := yoloConfig.FindOrphanedResources(
+		managedResources,
+		time.Now(),
+	)
+
+	dryRun := !input.RemoveOrphanedResources || len(orphanedResources) == 0
+
+	// Nothing is removed in plan mode
+	if !dryRun && !input.PlanMode && configNotFound && !input.RemoveWithoutConfig {
+		return handleError(entities.ErrRemoveOrphanedResourcesWithoutConfig{})
+	}
+
+	if !dryRun && !input.PlanMode && !input.ForceRemove {
+		if input.ConfirmRemove == nil {
+			return handleError(entities.ErrRemoveNotConfirmed{})
+		}
+
+		g.stepper.StopCurrentStep()
+
+		confirmed, err := input.ConfirmRemove(orphanedResources)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		// The orphaned resources are still reported
+		dryRun = !confirmed
+
+		g.stepper.StartTemporaryStep(step)
+	}
+
+	removedResources := []entities.ManagedResource{}
+
+	if !dryRun {
+		g.stepper.StartTemporaryStep("Removing orphaned resources")
+
+		for _, resource := range orphanedResources {
+			err = cloudService.RemoveManagedResource(
+				g.stepper,
+				resource,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+
+			removedResources = append(removedResources, resource)
+		}
+	}
+
+	return g.outputHandler.HandleOutput(GarbageCollectOutput{
+		Stepper: g.stepper,
+		Content: &GarbageCollectOutputContent{
+			DryRun:            dryRun,
+			ConfigNotFound:    configNotFound,
+			OrphanedResources: orphanedResources,
+			RemovedResources:  removedResources,
+			Plan:              plan,
+		},
+	})
+}
@@ -0,0 +1,185 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type IdleStopInput struct {
+	IdleReporter entities.IdleReporter
+	PlanMode     bool
+}
+
+type IdleStopOutput struct {
+	// Set along with "Content" when some envs failed to be evaluated or stopped
+	Error   error
+	Content *IdleStopOutputContent
+	Stepper stepper.Stepper
+}
+
+type IdleEnv struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	// Nil when the reporter has no activity report for the env
+	Report  *entities.IdleReport
+	Idle    bool
+	Stopped bool
+	// Errors are reported per env in order to
+	// not block the evaluation of the other ones
+	Error error
+}
+
+type IdleStopOutputContent struct {
+	// Only the envs with an enabled idle policy are evaluated
+	EvaluatedEnvs []IdleEnv
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type IdleStopOutputHandler interface {
+	HandleOutput(IdleStopOutput) error
+}
+
+type IdleStopFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       IdleStopOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewIdleStopFeature(
+	stepper stepper.Stepper,
+	outputHandler IdleStopOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) IdleStopFeature {
+
+	return IdleStopFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (i IdleStopFeature) Execute(input IdleStopInput) error {
+	handleError := func(err error) error {
+		i.outputHandler.HandleOutput(IdleStopOutput{
+			Stepper: i.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	if input.IdleReporter == nil {
+		return handleError(errors.New("passed idle reporter is nil"))
+	}
+
+	i.stepper.StartTemporaryStep("Looking for idle environments")
+
+	cloudService, err := i.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		i.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	now := time.Now()
+	evaluatedEnvs := []IdleEnv{}
+	failedEnvsCount := 0
+
+	for _, clusterName := range sortedClusterNames(yoloConfig) {
+		cluster := yoloConfig.Clusters[clusterName]
+
+		for _, envName := range sortedEnvNames(cluster) {
+			env := cluster.Envs[envName]
+			idlePolicy := env.GetIdlePolicy(cluster)
+
+			if idlePolicy == nil || !idlePolicy.IsEnabled() {
+				continue
+			}
+
+			// Only running envs could be stopped
+			if env.Status != entities.EnvStatusCreated {
+				continue
+			}
+
+			idleEnv := IdleEnv{
+				Cluster: cluster,
+				Env:     env,
+			}
+
+			idleEnv.Report, idleEnv.Error = input.IdleReporter.Report(
+				cloudService,
+				yoloConfig,
+				cluster,
+				env,
+			)
+
+			// Envs without activity report are considered as
+			// active given that stopping them may lose some work
+			if idleEnv.Error == nil && idleEnv.Report != nil {
+				idleEnv.Idle = idlePolicy.IsEnvIdle(*idleEnv.Report, now)
+			}
+
+			if idleEnv.Idle {
+				i.stepper.StartTemporaryStep(
+					fmt.Sprintf("Stopping the idle environment \"%s\"", env.Name),
+				)
+
+				idleEnv.Error = actions.StopEnv(
+					i.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					entities.EnvStopReasonIdle,
+				)
+
+				idleEnv.Stopped = idleEnv.Error == nil
+			}
+
+			if idleEnv.Error != nil {
+				failedEnvsCount++
+			}
+
+			evaluatedEnvs = append(evaluatedEnvs, idleEnv)
+		}
+	}
+
+	var idleStopErr error
+
+	if failedEnvsCount > 0 {
+		idleStopErr = entities.ErrIdleStopEnvs{
+			FailedEnvsCount: failedEnvsCount,
+		}
+	}
+
+	i.outputHandler.HandleOutput(IdleStopOutput{
+		Stepper: i.stepper,
+		Error:   idleStopErr,
+		Content: &IdleStopOutputContent{
+			EvaluatedEnvs: evaluatedEnvs,
+			Plan:          plan,
+		},
+	})
+
+	return idleStopErr
+}
@@ -3,6 +3,7 @@ package features
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/yolo-sh/yolo/actions"
 	"github.com/yolo-sh/yolo/entities"
@@ -10,8 +11,28 @@ import (
 )
 
 type InitInput struct {
+	// Empty means that the instance type set in the project manifest,
+	// suggested from the devcontainer configuration or recommended
+	// from the repository languages (in this order) is used
 	InstanceType       string
 	ResolvedRepository entities.ResolvedEnvRepository
+	// Zero means that the TTL set in the project manifest (if any)
+	// is used and that, without manifest, the env never expires
+	TTL      time.Duration
+	PlanMode bool
+	// Empty means that a user key with read-write access is used
+	SSHKeyStrategy       entities.EnvSSHKeyStrategy
+	RepositoryAccessMode entities.EnvRepositoryAccessMode
+	// Empty means that the default algorithm is used.
+	// Zero bits means that the default size of the algorithm is used.
+	SSHKeyPairAlgorithm entities.EnvSSHKeyPairAlgorithm
+	SSHKeyPairBits      int
+	// Used to pin the repository ref (see "ResolvedEnvRepository.Ref")
+	// and to load the project manifest and the devcontainer configuration
+	// from it. Nil means that the ref is not pinned and nothing is loaded
+	VCSProvider    entities.VCSProvider
+	VCSAccessToken string
+	ReservedPorts  []string
 }
 
 type InitOutput struct {
@@ -21,12 +42,21 @@ type InitOutput struct {
 }
 
 type InitOutputContent struct {
-	CloudService    entities.CloudService
-	YoloConfig      *entities.Config
-	Cluster         *entities.Cluster
-	Env             *entities.Env
+	CloudService entities.CloudService
+	YoloConfig   *entities.Config
+	Cluster      *entities.Cluster
+	Env          *entities.Env
+	// False and nil in plan mode given
+	// that no env is actually created
 	EnvCreated      bool
 	SetEnvAsCreated func() error
+	// Nil when the repository doesn't have a project manifest
+	ProjectManifest *entities.ProjectManifest
+	// Nil when the repository doesn't have a devcontainer configuration
+	DevContainer               *entities.DevContainer
+	InstanceTypeRecommendation entities.InstanceTypeRecommendation
+	// Set only in plan mode
+	Plan *entities.Plan
 }
 
 type InitOutputHandler interface {
@@ -62,6 +92,72 @@ func (i InitFeature) Execute(input InitInput) error {
 		return err
 	}
 
+	if len(input.ResolvedRepository.Variant) > 0 {
+		err := entities.CheckEnvRepositoryVariantValidity(
+			input.ResolvedRepository.Variant,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	if len(input.SSHKeyStrategy) > 0 {
+		err := entities.CheckEnvSSHKeyStrategyValidity(
+			input.SSHKeyStrategy,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	if len(input.RepositoryAccessMode) > 0 {
+		err := entities.CheckEnvRepositoryAccessModeValidity(
+			input.RepositoryAccessMode,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	// The SSH key strategy is checked before any resource is created
+	// given that the env key is only registered once the env is created
+	if input.VCSProvider != nil &&
+		input.SSHKeyStrategy == entities.EnvSSHKeyStrategyDeployKey &&
+		input.RepositoryAccessMode != entities.EnvRepositoryAccessModeReadOnly &&
+		!input.VCSProvider.SupportsReadWriteDeployKeys() {
+
+		return handleError(entities.ErrReadWriteDeployKeyNotSupported{
+			VCSProviderHost: input.VCSProvider.Host(),
+		})
+	}
+
+	if input.VCSProvider != nil &&
+		input.SSHKeyStrategy != entities.EnvSSHKeyStrategyDeployKey &&
+		!input.VCSProvider.SupportsUserKeys() {
+
+		return handleError(entities.ErrUserKeysNotSupported{
+			VCSProviderHost: input.VCSProvider.Host(),
+		})
+	}
+
+	sshKeyPairAlgorithm := input.SSHKeyPairAlgorithm
+
+	if len(sshKeyPairAlgorithm) == 0 {
+		sshKeyPairAlgorithm = entities.DefaultEnvSSHKeyPairAlgorithm
+	}
+
+	err := entities.CheckEnvSSHKeyPairSizeValidity(
+		sshKeyPairAlgorithm,
+		input.SSHKeyPairBits,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
 	envName := entities.BuildEnvNameFromResolvedRepo(
 		input.ResolvedRepository,
 	)
@@ -69,26 +165,162 @@ func (i InitFeature) Execute(input InitInput) error {
 	step := fmt.Sprintf("Initializing an environment for \"%s\"", envName)
 	i.stepper.StartTemporaryStep(step)
 
+	var projectManifest *entities.ProjectManifest
+	var devContainer *entities.DevContainer
+
+	resolvedRepository := input.ResolvedRepository
+
+	if input.VCSProvider != nil {
+		i.stepper.StartTemporaryStep("Resolving the repository ref")
+
+		// The requested ref (empty for the default branch)
+		// is pinned to the commit that the env is built from
+		resolvedRef, err := input.VCSProvider.ResolveRepositoryRef(
+			input.VCSAccessToken,
+			resolvedRepository.Owner,
+			resolvedRepository.Name,
+			resolvedRepository.Ref,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		resolvedRepository.PinRef(*resolvedRef)
+
+		i.stepper.StartTemporaryStep("Loading the project configuration")
+
+		manifest, err := loadProjectManifest(
+			input.VCSProvider,
+			input.VCSAccessToken,
+			resolvedRepository,
+			input.ReservedPorts,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		projectManifest = manifest
+
+		devContainer, err = loadDevContainer(
+			input.VCSProvider,
+			input.VCSAccessToken,
+			resolvedRepository,
+			input.ReservedPorts,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		i.stepper.StartTemporaryStep(step)
+	}
+
+	instanceType := input.InstanceType
+	ttl := input.TTL
+	portsToOpen := []string{}
+
+	if projectManifest != nil {
+		if len(instanceType) == 0 {
+			instanceType = projectManifest.InstanceType
+		}
+
+		if ttl == 0 {
+			// Validated when loaded
+			ttl, _ = projectManifest.GetTTL()
+		}
+
+		portsToOpen = append(portsToOpen, projectManifest.Ports...)
+	}
+
+	if devContainer != nil {
+		portsToOpen = append(portsToOpen, devContainer.ForwardPorts...)
+	}
+
+	portSpecsToOpen := []entities.PortSpec{}
+
+	for _, port := range portsToOpen {
+		// Validated when loaded
+		portSpec, err := entities.ParsePortSpec(port)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		portSpecsToOpen = append(portSpecsToOpen, portSpec)
+	}
+
 	cloudService, err := i.cloudServiceBuilder.Build()
 
 	if err != nil {
 		return handleError(err)
 	}
 
-	err = cloudService.CheckInstanceTypeValidity(
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
 		i.stepper,
-		input.InstanceType,
 	)
 
-	if err != nil {
+	if err != nil && !errors.Is(err, entities.ErrYoloNotInstalled) {
 		return handleError(err)
 	}
 
-	yoloConfig, err := cloudService.LookupYoloConfig(
+	instanceTypeRules := entities.DefaultInstanceTypeRules
+
+	if yoloConfig != nil {
+		instanceTypeRules = yoloConfig.GetInstanceTypeRules()
+	}
+
+	instanceTypeRecommendation := entities.RecommendInstanceType(
+		resolvedRepository,
+		instanceTypeRules,
+	)
+
+	// The devcontainer requirements are explicit
+	// so they take precedence over the recommended ones
+	if len(instanceType) == 0 && devContainer != nil &&
+		!devContainer.HostRequirements.IsEmpty() {
+
+		instanceType, err = cloudService.SuggestInstanceType(
+			i.stepper,
+			devContainer.HostRequirements,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	if len(instanceType) == 0 {
+		instanceType = instanceTypeRecommendation.InstanceType
+	}
+
+	if len(instanceType) == 0 &&
+		!instanceTypeRecommendation.HostRequirements.IsEmpty() {
+
+		instanceType, err = cloudService.SuggestInstanceType(
+			i.stepper,
+			instanceTypeRecommendation.HostRequirements,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	err = cloudService.CheckInstanceTypeValidity(
 		i.stepper,
+		instanceType,
 	)
 
-	if err != nil && !errors.Is(err, entities.ErrYoloNotInstalled) {
+	if err != nil {
 		return handleError(err)
 	}
 
@@ -129,7 +361,7 @@ func (i InitFeature) Execute(input InitInput) error {
 
 			cluster = entities.NewCluster(
 				clusterName,
-				input.InstanceType,
+				instanceType,
 				isDefaultCluster,
 			)
 		}
@@ -169,11 +401,28 @@ func (i InitFeature) Execute(input InitInput) error {
 		in creating state after error */
 
 		if env == nil {
+			err = yoloConfig.CheckEnvNameSlugUniqueness(
+				cluster.Name,
+				envName,
+			)
+
+			if err != nil {
+				return handleError(err)
+			}
+
 			env = entities.NewEnv(
 				envName,
-				input.InstanceType,
-				input.ResolvedRepository,
+				instanceType,
+				resolvedRepository,
 			)
+
+			env.SetTTL(ttl, time.Now())
+			env.SSHKeyStrategy = input.SSHKeyStrategy
+			env.RepositoryAccessMode = input.RepositoryAccessMode
+			// The key pair is generated by the cloud
+			// service (see "sshkey.GenerateEnvKeyPair")
+			env.SSHKeyPairAlgorithm = sshKeyPairAlgorithm
+			env.SSHKeyPairBits = input.SSHKeyPairBits
 		}
 
 		err = actions.CreateEnv(
@@ -191,32 +440,104 @@ func (i InitFeature) Execute(input InitInput) error {
 		envCreated = true
 	}
 
-	// Current step is the last ended infrastructure step.
-	// Better UX if we reset to main step here given that
-	// the next steps (in GRPC agent) may take some time to start.
-	i.stepper.StartTemporaryStep(step)
+	if env.Status == entities.EnvStatusStopping ||
+		env.Status == entities.EnvStatusStopped ||
+		env.Status == entities.EnvStatusStarting {
+
+		/* Env stopped or still in
+		stopping / starting state after error */
+
+		i.stepper.StartTemporaryStep("Starting the environment")
+
+		err = actions.StartEnv(
+			i.stepper,
+			cloudService,
+			yoloConfig,
+			cluster,
+			env,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
 
-	setEnvAsCreated := func() error {
-		env.Status = entities.EnvStatusCreated
+	portsNotOpened := []entities.PortSpec{}
 
-		return actions.UpdateEnvInConfig(
+	// Ports may be declared in both the manifest and the devcontainer
+	for _, portSpec := range dedupePortSpecs(portSpecsToOpen) {
+		if !env.IsPortOpened(portSpec) {
+			portsNotOpened = append(portsNotOpened, portSpec)
+		}
+	}
+
+	if len(portsNotOpened) > 0 {
+		i.stepper.StartTemporaryStep(
+			fmt.Sprintf("Opening %d port(s)", len(portsNotOpened)),
+		)
+
+		openedBy := resolvePortOpener(
+			input.VCSProvider,
+			input.VCSAccessToken,
+		)
+
+		err = actions.OpenPorts(
 			i.stepper,
 			cloudService,
 			yoloConfig,
 			cluster,
 			env,
+			portsNotOpened,
+			entities.NewEnvOpenedPortMetadata(
+				"Declared in the project configuration",
+				openedBy,
+				0,
+				time.Now(),
+			),
 		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	// Current step is the last ended infrastructure step.
+	// Better UX if we reset to main step here given that
+	// the next steps (in GRPC agent) may take some time to start.
+	i.stepper.StartTemporaryStep(step)
+
+	var setEnvAsCreated func() error
+
+	if input.PlanMode {
+		// Front-ends must not connect to a planned env
+		envCreated = false
+	} else {
+		setEnvAsCreated = func() error {
+			env.Status = entities.EnvStatusCreated
+
+			return actions.UpdateEnvInConfig(
+				i.stepper,
+				cloudService,
+				yoloConfig,
+				cluster,
+				env,
+			)
+		}
 	}
 
 	return i.outputHandler.HandleOutput(InitOutput{
 		Stepper: i.stepper,
 		Content: &InitOutputContent{
-			CloudService:    cloudService,
-			YoloConfig:      yoloConfig,
-			Cluster:         cluster,
-			Env:             env,
-			EnvCreated:      envCreated,
-			SetEnvAsCreated: setEnvAsCreated,
+			CloudService:               cloudService,
+			YoloConfig:                 yoloConfig,
+			Cluster:                    cluster,
+			Env:                        env,
+			EnvCreated:                 envCreated,
+			SetEnvAsCreated:            setEnvAsCreated,
+			ProjectManifest:            projectManifest,
+			DevContainer:               devContainer,
+			InstanceTypeRecommendation: instanceTypeRecommendation,
+			Plan:                       plan,
 		},
 	})
 }
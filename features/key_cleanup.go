@@ -0,0 +1,177 @@
+package features
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type KeyCleanupInput struct {
+	VCSProvider    entities.VCSProvider
+	VCSAccessToken string
+	// Unknown keys are only listed (dry run)
+	// unless "RemoveUnknownKeys" is set to true
+	RemoveUnknownKeys bool
+	// The keys may belong to another Yolo installation so the removal
+	// is refused unless "ForceRemove" is set or "ConfirmRemove" returns true
+	ForceRemove   bool
+	ConfirmRemove func(sshKeys, gpgKeys []entities.VCSKey) (bool, error)
+}
+
+type KeyCleanupOutput struct {
+	Error   error
+	Content *KeyCleanupOutputContent
+	Stepper stepper.Stepper
+}
+
+type KeyCleanupOutputContent struct {
+	// Also true when the removal was not confirmed
+	DryRun         bool
+	UnknownSSHKeys []entities.VCSKey
+	UnknownGPGKeys []entities.VCSKey
+	RemovedSSHKeys []entities.VCSKey
+	RemovedGPGKeys []entities.VCSKey
+}
+
+type KeyCleanupOutputHandler interface {
+	HandleOutput(KeyCleanupOutput) error
+}
+
+// KeyCleanupFeature removes the keys registered by Yolo that are
+// not referenced by any env in the config (see "FindUnknownVCSKeys").
+type KeyCleanupFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       KeyCleanupOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewKeyCleanupFeature(
+	stepper stepper.Stepper,
+	outputHandler KeyCleanupOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) KeyCleanupFeature {
+
+	return KeyCleanupFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (k KeyCleanupFeature) Execute(input KeyCleanupInput) error {
+	handleError := func(err error) error {
+		k.outputHandler.HandleOutput(KeyCleanupOutput{
+			Stepper: k.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	step := "Looking for unknown keys"
+	k.stepper.StartTemporaryStep(step)
+
+	cloudService, err := k.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Unlike cloud resources, keys may be shared between the Yolo
+	// installations of the same user (eg: on multiple cloud providers).
+	// As a result, a missing config is an error here.
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		k.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	sshKeys, err := input.VCSProvider.ListRegisteredSSHKeys(
+		input.VCSAccessToken,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	gpgKeys, err := input.VCSProvider.ListRegisteredGPGKeys(
+		input.VCSAccessToken,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	unknownSSHKeys := yoloConfig.FindUnknownVCSKeys(sshKeys)
+	// GPG keys are only matched by title so
+	// the unnamed ones are never considered as unknown
+	unknownGPGKeys := yoloConfig.FindUnknownVCSKeys(gpgKeys)
+
+	dryRun := !input.RemoveUnknownKeys ||
+		len(unknownSSHKeys)+len(unknownGPGKeys) == 0
+
+	if !dryRun && !input.ForceRemove {
+		if input.ConfirmRemove == nil {
+			return handleError(entities.ErrRemoveNotConfirmed{})
+		}
+
+		k.stepper.StopCurrentStep()
+
+		confirmed, err := input.ConfirmRemove(unknownSSHKeys, unknownGPGKeys)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		// The unknown keys are still reported
+		dryRun = !confirmed
+
+		k.stepper.StartTemporaryStep(step)
+	}
+
+	removedSSHKeys := []entities.VCSKey{}
+	removedGPGKeys := []entities.VCSKey{}
+
+	if !dryRun {
+		k.stepper.StartTemporaryStep("Removing unknown keys")
+
+		for _, key := range unknownSSHKeys {
+			err = input.VCSProvider.UnregisterSSHKey(
+				input.VCSAccessToken,
+				key.ID,
+			)
+
+			// Key may have been removed concurrently
+			if err != nil && !input.VCSProvider.IsNotFoundError(err) {
+				return handleError(err)
+			}
+
+			removedSSHKeys = append(removedSSHKeys, key)
+		}
+
+		for _, key := range unknownGPGKeys {
+			err = input.VCSProvider.UnregisterGPGKey(
+				input.VCSAccessToken,
+				key.ID,
+			)
+
+			if err != nil && !input.VCSProvider.IsNotFoundError(err) {
+				return handleError(err)
+			}
+
+			removedGPGKeys = append(removedGPGKeys, key)
+		}
+	}
+
+	return k.outputHandler.HandleOutput(KeyCleanupOutput{
+		Stepper: k.stepper,
+		Content: &KeyCleanupOutputContent{
+			DryRun:         dryRun,
+			UnknownSSHKeys: unknownSSHKeys,
+			UnknownGPGKeys: unknownGPGKeys,
+			RemovedSSHKeys: removedSSHKeys,
+			RemovedGPGKeys: removedGPGKeys,
+		},
+	})
+}
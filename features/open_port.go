@@ -2,6 +2,7 @@ package features
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/yolo-sh/yolo/actions"
 	"github.com/yolo-sh/yolo/entities"
@@ -10,7 +11,18 @@ import (
 
 type OpenPortInput struct {
 	ResolvedRepository entities.ResolvedEnvRepository
-	PortToOpen         string
+	PortToOpen         entities.PortSpec
+	PlanMode           bool
+	ReservedPorts      []string
+	// eg: "webpack dev server"
+	Label string
+	// Used to record the user that opened the port
+	// (see "EnvOpenedPortMetadata.OpenedBy").
+	// Nil means that the user is not recorded
+	VCSProvider    entities.VCSProvider
+	VCSAccessToken string
+	// Zero means that the port never expires (see "ExpirePortsFeature")
+	TTL time.Duration
 }
 
 type OpenPortOutput struct {
@@ -22,8 +34,10 @@ type OpenPortOutput struct {
 type OpenPortOutputContent struct {
 	Cluster           *entities.Cluster
 	Env               *entities.Env
-	PortOpened        string
+	PortOpened        entities.PortSpec
 	PortAlreadyOpened bool
+	// Set only in plan mode
+	Plan *entities.Plan
 }
 
 type OpenPortOutputHandler interface {
@@ -59,6 +73,12 @@ func (o OpenPortFeature) Execute(input OpenPortInput) error {
 		return err
 	}
 
+	err := input.PortToOpen.Validate(input.ReservedPorts)
+
+	if err != nil {
+		return handleError(err)
+	}
+
 	envName := entities.BuildEnvNameFromResolvedRepo(
 		input.ResolvedRepository,
 	)
@@ -66,7 +86,7 @@ func (o OpenPortFeature) Execute(input OpenPortInput) error {
 	o.stepper.StartTemporaryStep(
 		fmt.Sprintf(
 			"Opening port \"%s\"",
-			input.PortToOpen,
+			input.PortToOpen.String(),
 		),
 	)
 
@@ -76,6 +96,13 @@ func (o OpenPortFeature) Execute(input OpenPortInput) error {
 		return handleError(err)
 	}
 
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
 	yoloConfig, err := cloudService.LookupYoloConfig(
 		o.stepper,
 	)
@@ -109,9 +136,14 @@ func (o OpenPortFeature) Execute(input OpenPortInput) error {
 		})
 	}
 
-	portAlreadyOpened := env.OpenedPorts[input.PortToOpen]
+	portAlreadyOpened := env.IsPortOpened(input.PortToOpen)
 
 	if !portAlreadyOpened {
+		openedBy := resolvePortOpener(
+			input.VCSProvider,
+			input.VCSAccessToken,
+		)
+
 		err = actions.OpenPort(
 			o.stepper,
 			cloudService,
@@ -119,6 +151,12 @@ func (o OpenPortFeature) Execute(input OpenPortInput) error {
 			cluster,
 			env,
 			input.PortToOpen,
+			entities.NewEnvOpenedPortMetadata(
+				input.Label,
+				openedBy,
+				input.TTL,
+				time.Now(),
+			),
 		)
 
 		if err != nil {
@@ -133,6 +171,7 @@ func (o OpenPortFeature) Execute(input OpenPortInput) error {
 			Env:               env,
 			PortOpened:        input.PortToOpen,
 			PortAlreadyOpened: portAlreadyOpened,
+			Plan:              plan,
 		},
 	})
 }
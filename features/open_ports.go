@@ -0,0 +1,201 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type OpenPortsInput struct {
+	ResolvedRepository entities.ResolvedEnvRepository
+	PortsToOpen        []entities.PortSpec
+	PlanMode           bool
+	ReservedPorts      []string
+	// eg: "webpack dev server"
+	Label string
+	// Used to record the user that opened the ports
+	// (see "EnvOpenedPortMetadata.OpenedBy").
+	// Nil means that the user is not recorded
+	VCSProvider    entities.VCSProvider
+	VCSAccessToken string
+	// Zero means that the port never expires (see "ExpirePortsFeature")
+	TTL time.Duration
+}
+
+type OpenPortsOutput struct {
+	// Set along with "Content" when the ports failed to open.
+	// The batch is rolled back so the ports are usually all failed.
+	Error   error
+	Content *OpenPortsOutputContent
+	Stepper stepper.Stepper
+}
+
+type OpenPortsOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	// In the order of the ports to open (without duplicates)
+	Results []PortResult
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type OpenPortsOutputHandler interface {
+	HandleOutput(OpenPortsOutput) error
+}
+
+type OpenPortsFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       OpenPortsOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewOpenPortsFeature(
+	stepper stepper.Stepper,
+	outputHandler OpenPortsOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) OpenPortsFeature {
+
+	return OpenPortsFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (o OpenPortsFeature) Execute(input OpenPortsInput) error {
+	handleError := func(err error) error {
+		o.outputHandler.HandleOutput(OpenPortsOutput{
+			Stepper: o.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	// All the ports are validated before any
+	// of them is opened to keep the batch atomic
+	for _, portToOpen := range input.PortsToOpen {
+		err := portToOpen.Validate(input.ReservedPorts)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	portsToOpen := dedupePortSpecs(input.PortsToOpen)
+
+	envName := entities.BuildEnvNameFromResolvedRepo(
+		input.ResolvedRepository,
+	)
+
+	o.stepper.StartTemporaryStep(
+		fmt.Sprintf("Opening %d port(s)", len(portsToOpen)),
+	)
+
+	cloudService, err := o.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		o.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := yoloConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := yoloConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrOpenPortRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrOpenPortCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	alreadyOpenedPorts := map[string]bool{}
+	portsNotOpened := []entities.PortSpec{}
+
+	for _, portToOpen := range portsToOpen {
+		if env.IsPortOpened(portToOpen) {
+			alreadyOpenedPorts[portToOpen.String()] = true
+			continue
+		}
+
+		portsNotOpened = append(portsNotOpened, portToOpen)
+	}
+
+	var openPortsErr error
+
+	if len(portsNotOpened) > 0 {
+		openedBy := resolvePortOpener(
+			input.VCSProvider,
+			input.VCSAccessToken,
+		)
+
+		openPortsErr = actions.OpenPorts(
+			o.stepper,
+			cloudService,
+			yoloConfig,
+			cluster,
+			env,
+			portsNotOpened,
+			entities.NewEnvOpenedPortMetadata(
+				input.Label,
+				openedBy,
+				input.TTL,
+				time.Now(),
+			),
+		)
+	}
+
+	results := buildPortResults(
+		portsToOpen,
+		alreadyOpenedPorts,
+		env.IsPortOpened,
+		PortResultStatusAlreadyOpened,
+		PortResultStatusOpened,
+		openPortsErr,
+	)
+
+	o.outputHandler.HandleOutput(OpenPortsOutput{
+		Stepper: o.stepper,
+		Error:   openPortsErr,
+		Content: &OpenPortsOutputContent{
+			Cluster: cluster,
+			Env:     env,
+			Results: results,
+			Plan:    plan,
+		},
+	})
+
+	return openPortsErr
+}
@@ -0,0 +1,106 @@
+package features
+
+import "github.com/yolo-sh/yolo/entities"
+
+type PortResultStatus string
+
+const (
+	PortResultStatusOpened        PortResultStatus = "opened"
+	PortResultStatusAlreadyOpened PortResultStatus = "already_opened"
+	PortResultStatusClosed        PortResultStatus = "closed"
+	PortResultStatusAlreadyClosed PortResultStatus = "already_closed"
+	PortResultStatusFailed        PortResultStatus = "failed"
+)
+
+// PortResult represents the result of
+// a batch operation for one port.
+type PortResult struct {
+	Port   entities.PortSpec
+	Status PortResultStatus
+	// Set only for the "failed" status
+	Error error
+}
+
+// buildPortResults returns the result of a batch operation for each
+// passed port, in the same order:
+//
+//   - the ports set in "skippedPorts" were not part of the batch
+//     given that they were already handled (eg: already opened);
+//
+//   - the other ones are considered as handled when "isPortHandled"
+//     returns true once the batch has run. The batch error is set
+//     on the ones that are not.
+func buildPortResults(
+	ports []entities.PortSpec,
+	skippedPorts map[string]bool,
+	isPortHandled func(entities.PortSpec) bool,
+	skippedStatus PortResultStatus,
+	handledStatus PortResultStatus,
+	batchErr error,
+) []PortResult {
+
+	results := make([]PortResult, len(ports))
+
+	for portIndex, port := range ports {
+		results[portIndex].Port = port
+
+		if skippedPorts[port.String()] {
+			results[portIndex].Status = skippedStatus
+			continue
+		}
+
+		// The batches are rolled back on error (see "actions.OpenPorts")
+		// so the ports are handled only when the rollback has failed
+		if batchErr == nil || isPortHandled(port) {
+			results[portIndex].Status = handledStatus
+			continue
+		}
+
+		results[portIndex].Status = PortResultStatusFailed
+		results[portIndex].Error = batchErr
+	}
+
+	return results
+}
+
+// resolvePortOpener returns the username recorded in the metadata
+// of the opened ports (see "EnvOpenedPortMetadata.OpenedBy").
+//
+// The username is empty when no VCS provider is passed or when it
+// can't be retrieved given that it is informative only and must
+// not prevent the ports from being opened.
+func resolvePortOpener(
+	vcsProvider entities.VCSProvider,
+	vcsAccessToken string,
+) string {
+
+	if vcsProvider == nil {
+		return ""
+	}
+
+	username, err := vcsProvider.GetAuthenticatedUsername(vcsAccessToken)
+
+	if err != nil {
+		return ""
+	}
+
+	return username
+}
+
+// dedupePortSpecs removes the duplicated port specs
+// from the passed ones while keeping their order.
+func dedupePortSpecs(portSpecs []entities.PortSpec) []entities.PortSpec {
+	handledPortSpecs := map[string]bool{}
+	dedupedPortSpecs := []entities.PortSpec{}
+
+	for _, portSpec := range portSpecs {
+		if handledPortSpecs[portSpec.String()] {
+			continue
+		}
+
+		handledPortSpecs[portSpec.String()] = true
+		dedupedPortSpecs = append(dedupedPortSpecs, portSpec)
+	}
+
+	return dedupedPortSpecs
+}
@@ -0,0 +1,158 @@
+package features
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+func TestDedupePortSpecs(t *testing.T) {
+	portSpecs := []entities.PortSpec{
+		entities.NewTCPPortSpec(8080),
+		entities.NewTCPPortSpec(3000),
+		entities.NewTCPPortSpec(8080),
+		{
+			Protocol:    entities.PortProtocolUDP,
+			FromPort:    8080,
+			ToPort:      8080,
+			SourceCIDRs: []string{},
+		},
+	}
+
+	dedupedPortSpecs := dedupePortSpecs(portSpecs)
+	expectedPortSpecs := []entities.PortSpec{
+		portSpecs[0],
+		portSpecs[1],
+		portSpecs[3],
+	}
+
+	if !reflect.DeepEqual(expectedPortSpecs, dedupedPortSpecs) {
+		t.Fatalf(
+			"expected port specs to equal '%+v', got '%+v'",
+			expectedPortSpecs,
+			dedupedPortSpecs,
+		)
+	}
+}
+
+func TestBuildPortResults(t *testing.T) {
+	batchErr := errors.New("ErrOpenPorts")
+
+	alreadyOpenedPort := entities.NewTCPPortSpec(22)
+	openedPort := entities.NewTCPPortSpec(8080)
+	failedPort := entities.NewTCPPortSpec(3000)
+
+	ports := []entities.PortSpec{
+		alreadyOpenedPort,
+		openedPort,
+		failedPort,
+	}
+
+	skippedPorts := map[string]bool{
+		alreadyOpenedPort.String(): true,
+	}
+
+	// The rollback failed for "openedPort"
+	isPortOpened := func(portSpec entities.PortSpec) bool {
+		return portSpec.String() != failedPort.String()
+	}
+
+	testCases := []struct {
+		test            string
+		batchErr        error
+		expectedResults []PortResult
+	}{
+		{
+			test:     "without error",
+			batchErr: nil,
+			expectedResults: []PortResult{
+				{Port: alreadyOpenedPort, Status: PortResultStatusAlreadyOpened},
+				{Port: openedPort, Status: PortResultStatusOpened},
+				{Port: failedPort, Status: PortResultStatusOpened},
+			},
+		},
+
+		{
+			test:     "with error",
+			batchErr: batchErr,
+			expectedResults: []PortResult{
+				{Port: alreadyOpenedPort, Status: PortResultStatusAlreadyOpened},
+				{Port: openedPort, Status: PortResultStatusOpened},
+				{Port: failedPort, Status: PortResultStatusFailed, Error: batchErr},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			results := buildPortResults(
+				ports,
+				skippedPorts,
+				isPortOpened,
+				PortResultStatusAlreadyOpened,
+				PortResultStatusOpened,
+				tc.batchErr,
+			)
+
+			if !reflect.DeepEqual(tc.expectedResults, results) {
+				t.Fatalf(
+					"expected results to equal '%+v', got '%+v'",
+					tc.expectedResults,
+					results,
+				)
+			}
+		})
+	}
+}
+
+type usernameVCSProvider struct {
+	entities.VCSProvider
+	username string
+	err      error
+}
+
+func (u usernameVCSProvider) GetAuthenticatedUsername(accessToken string) (string, error) {
+	return u.username, u.err
+}
+
+func TestResolvePortOpener(t *testing.T) {
+	testCases := []struct {
+		test             string
+		vcsProvider      entities.VCSProvider
+		expectedOpenedBy string
+	}{
+		{
+			test:             "without VCS provider",
+			vcsProvider:      nil,
+			expectedOpenedBy: "",
+		},
+
+		{
+			test: "with authenticated user",
+			vcsProvider: usernameVCSProvider{
+				username: "jeremylevy",
+			},
+			expectedOpenedBy: "jeremylevy",
+		},
+
+		{
+			test: "with VCS provider error",
+			vcsProvider: usernameVCSProvider{
+				err: errors.New("rate limited"),
+			},
+			expectedOpenedBy: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			openedBy := resolvePortOpener(tc.vcsProvider, "access_token")
+
+			if openedBy != tc.expectedOpenedBy {
+				t.Fatalf("expected opened by to equal '%s', got '%s'", tc.expectedOpenedBy, openedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package features
+
+import (
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// loadProjectManifest loads the project manifest from
+// the repository of the passed env, at its pinned ref.
+//
+// A nil manifest is returned when the repository doesn't have one.
+func loadProjectManifest(
+	vcsProvider entities.VCSProvider,
+	vcsAccessToken string,
+	resolvedRepository entities.ResolvedEnvRepository,
+	reservedPorts []string,
+) (*entities.ProjectManifest, error) {
+
+	manifestContent, err := vcsProvider.GetFileContentFromRepository(
+		vcsAccessToken,
+		resolvedRepository.Owner,
+		resolvedRepository.Name,
+		entities.ProjectManifestFilePath,
+		resolvedRepository.GetPinnedRef(),
+	)
+
+	if vcsProvider.IsNotFoundError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := entities.ParseProjectManifest(manifestContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = manifest.Validate(reservedPorts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
@@ -13,6 +13,7 @@ type RemoveInput struct {
 	PreRemoveHook      entities.HookRunner
 	ForceRemove        bool
 	ConfirmRemove      func() (bool, error)
+	PlanMode           bool
 }
 
 type RemoveOutput struct {
@@ -24,6 +25,8 @@ type RemoveOutput struct {
 type RemoveOutputContent struct {
 	Cluster *entities.Cluster
 	Env     *entities.Env
+	// Set only in plan mode
+	Plan *entities.Plan
 }
 
 type RemoveOutputHandler interface {
@@ -72,6 +75,13 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 		return handleError(err)
 	}
 
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
 	yoloConfig, err := cloudService.LookupYoloConfig(
 		r.stepper,
 	)
@@ -93,7 +103,14 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 		return handleError(err)
 	}
 
-	if !input.ForceRemove && input.ConfirmRemove != nil {
+	preRemoveHook := input.PreRemoveHook
+
+	if input.PlanMode {
+		// The pre-remove hook may modify external resources
+		preRemoveHook = nil
+	}
+
+	if !input.PlanMode && !input.ForceRemove && input.ConfirmRemove != nil {
 		r.stepper.StopCurrentStep()
 
 		confirmed, err := input.ConfirmRemove()
@@ -115,7 +132,7 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 		yoloConfig,
 		cluster,
 		env,
-		input.PreRemoveHook,
+		preRemoveHook,
 	)
 
 	if err != nil {
@@ -127,6 +144,7 @@ func (r RemoveFeature) Execute(input RemoveInput) error {
 		Content: &RemoveOutputContent{
 			Cluster: cluster,
 			Env:     env,
+			Plan:    plan,
 		},
 	})
 }
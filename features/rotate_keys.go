@@ -0,0 +1,345 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/sshkey"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type RotateKeysInput struct {
+	ResolvedRepository entities.ResolvedEnvRepository
+	VCSProvider        entities.VCSProvider
+	VCSAccessToken     string
+	PlanMode           bool
+	// Empty means that the algorithm of the current key pair is used.
+	// Zero bits means that the default size of the algorithm is used.
+	SSHKeyPairAlgorithm entities.EnvSSHKeyPairAlgorithm
+	SSHKeyPairBits      int
+}
+
+type RotateKeysOutput struct {
+	Error   error
+	Content *RotateKeysOutputContent
+	Stepper stepper.Stepper
+}
+
+type RotateKeysOutputContent struct {
+	Cluster *entities.Cluster
+	Env     *entities.Env
+	// Nil in plan mode
+	RegisteredVCSKey    *entities.VCSKey
+	UnregisteredVCSKeys []entities.VCSKey
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type RotateKeysOutputHandler interface {
+	HandleOutput(RotateKeysOutput) error
+}
+
+type RotateKeysFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       RotateKeysOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewRotateKeysFeature(
+	stepper stepper.Stepper,
+	outputHandler RotateKeysOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) RotateKeysFeature {
+
+	return RotateKeysFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (r RotateKeysFeature) Execute(input RotateKeysInput) error {
+	handleError := func(err error) error {
+		r.outputHandler.HandleOutput(RotateKeysOutput{
+			Stepper: r.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	if len(input.SSHKeyPairAlgorithm) > 0 {
+		err := entities.CheckEnvSSHKeyPairSizeValidity(
+			input.SSHKeyPairAlgorithm,
+			input.SSHKeyPairBits,
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	}
+
+	envName := entities.BuildEnvNameFromResolvedRepo(
+		input.ResolvedRepository,
+	)
+
+	step := fmt.Sprintf("Rotating the SSH keys of \"%s\"", envName)
+	r.stepper.StartTemporaryStep(step)
+
+	cloudService, err := r.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		r.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	clusterName := entities.DefaultClusterName
+	cluster, err := yoloConfig.GetCluster(clusterName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	env, err := yoloConfig.GetEnv(cluster.Name, envName)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	if env.Status == entities.EnvStatusRemoving {
+		return handleError(entities.ErrRotateKeysRemovingEnv{
+			EnvName: envName,
+		})
+	}
+
+	if env.Status == entities.EnvStatusCreating {
+		return handleError(entities.ErrRotateKeysCreatingEnv{
+			EnvName: envName,
+		})
+	}
+
+	// The new key pair needs to be installed in the instance
+	if env.Status == entities.EnvStatusStopping ||
+		env.Status == entities.EnvStatusStopped {
+
+		return handleError(entities.ErrRotateKeysStoppedEnv{
+			EnvName: envName,
+		})
+	}
+
+	keyPairAlgorithm := input.SSHKeyPairAlgorithm
+	keyPairBits := input.SSHKeyPairBits
+
+	if len(keyPairAlgorithm) == 0 {
+		keyPairAlgorithm = env.GetSSHKeyPairAlgorithm()
+
+		if keyPairBits == 0 {
+			keyPairBits = env.GetSSHKeyPairBits()
+		}
+	}
+
+	oldKeyTitle := env.GetSSHKeyPairName()
+	// The key pair name includes the algorithm
+	newKeyTitle := env.BuildSSHKeyPairName(keyPairAlgorithm)
+	useDeployKey := env.GetSSHKeyStrategy() == entities.EnvSSHKeyStrategyDeployKey
+	readOnly := env.GetRepositoryAccessMode() == entities.EnvRepositoryAccessModeReadOnly
+
+	if !useDeployKey && !input.VCSProvider.SupportsUserKeys() {
+		return handleError(entities.ErrUserKeysNotSupported{
+			VCSProviderHost: input.VCSProvider.Host(),
+		})
+	}
+
+	r.stepper.StartTemporaryStep("Looking for the current SSH keys")
+
+	var registeredKeys []entities.VCSKey
+
+	if useDeployKey {
+		registeredKeys, err = input.VCSProvider.ListRegisteredDeployKeys(
+			input.VCSAccessToken,
+			env.ResolvedRepository.Owner,
+			env.ResolvedRepository.Name,
+		)
+	} else {
+		registeredKeys, err = input.VCSProvider.ListRegisteredSSHKeys(
+			input.VCSAccessToken,
+		)
+	}
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	// Listed before the registration given that the new
+	// key may be registered with the same title
+	oldKeys := []entities.VCSKey{}
+
+	for _, key := range registeredKeys {
+		if key.Title == oldKeyTitle {
+			oldKeys = append(oldKeys, key)
+		}
+	}
+
+	vcsKeyResourceType := "vcs_ssh_key"
+
+	if useDeployKey {
+		vcsKeyResourceType = "vcs_deploy_key"
+	}
+
+	unregisterKey := func(keyID string) error {
+		if useDeployKey {
+			return input.VCSProvider.UnregisterDeployKey(
+				input.VCSAccessToken,
+				env.ResolvedRepository.Owner,
+				env.ResolvedRepository.Name,
+				keyID,
+			)
+		}
+
+		return input.VCSProvider.UnregisterSSHKey(
+			input.VCSAccessToken,
+			keyID,
+		)
+	}
+
+	var newKey *entities.VCSKey
+
+	if input.PlanMode {
+		plan.AddOperations(entities.PlannedOperation{
+			Action:       entities.PlannedOperationActionCreate,
+			ResourceType: vcsKeyResourceType,
+			ResourceName: newKeyTitle,
+			Description:  fmt.Sprintf("Register the new SSH key on \"%s\"", input.VCSProvider.Host()),
+		})
+
+		// No key pair is generated in plan mode so the planning cloud
+		// service is called directly to leave the env key pair untouched
+		err = cloudService.UpdateEnvSSHKeyPair(
+			r.stepper,
+			yoloConfig,
+			cluster,
+			env,
+			entities.EnvSSHKeyPair{
+				Algorithm: keyPairAlgorithm,
+				Bits:      keyPairBits,
+			},
+		)
+
+		if err != nil {
+			return handleError(err)
+		}
+	} else {
+		r.stepper.StartTemporaryStep("Generating a new SSH key pair")
+
+		keyPair, err := sshkey.GenerateKeyPair(keyPairAlgorithm, keyPairBits)
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		r.stepper.StartTemporaryStep("Registering the new SSH key")
+
+		if useDeployKey {
+			newKey, err = input.VCSProvider.RegisterDeployKey(
+				input.VCSAccessToken,
+				env.ResolvedRepository.Owner,
+				env.ResolvedRepository.Name,
+				newKeyTitle,
+				keyPair.PublicKeyContent,
+				readOnly,
+			)
+		} else {
+			newKey, err = input.VCSProvider.RegisterSSHKey(
+				input.VCSAccessToken,
+				newKeyTitle,
+				keyPair.PublicKeyContent,
+			)
+		}
+
+		if err != nil {
+			return handleError(err)
+		}
+
+		r.stepper.StartTemporaryStep("Installing the new SSH key pair")
+
+		// The env is persisted with the new key pair only once installed
+		// so that it remains accessible with the old one on error
+		err = actions.UpdateEnvSSHKeyPair(
+			r.stepper,
+			cloudService,
+			yoloConfig,
+			cluster,
+			env,
+			*keyPair,
+		)
+
+		// The env remains accessible with the new key pair only
+		// (returned in the error) so the new key is kept registered
+		if errors.As(err, &entities.ErrEnvSSHKeyPairNotPersisted{}) {
+			return handleError(err)
+		}
+
+		if err != nil {
+			// The new key pair is not installed so the new key is unregistered.
+			// The installation error is returned even if it fails.
+			unregisterKey(newKey.ID)
+
+			return handleError(err)
+		}
+	}
+
+	unregisteredKeys := []entities.VCSKey{}
+
+	if len(oldKeys) > 0 {
+		r.stepper.StartTemporaryStep("Removing the old SSH keys")
+	}
+
+	for _, key := range oldKeys {
+		if input.PlanMode {
+			plan.AddOperations(entities.PlannedOperation{
+				Action:       entities.PlannedOperationActionRemove,
+				ResourceType: vcsKeyResourceType,
+				ResourceName: key.Title,
+				Description:  fmt.Sprintf("Unregister the old SSH key \"%s\"", key.ID),
+			})
+
+			continue
+		}
+
+		err = unregisterKey(key.ID)
+
+		// Key may have been removed concurrently
+		if err != nil && !input.VCSProvider.IsNotFoundError(err) {
+			return handleError(err)
+		}
+
+		unregisteredKeys = append(unregisteredKeys, key)
+	}
+
+	return r.outputHandler.HandleOutput(RotateKeysOutput{
+		Stepper: r.stepper,
+		Content: &RotateKeysOutputContent{
+			Cluster:             cluster,
+			Env:                 env,
+			RegisteredVCSKey:    newKey,
+			UnregisteredVCSKeys: unregisteredKeys,
+			Plan:                plan,
+		},
+	})
+}
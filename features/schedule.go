@@ -0,0 +1,205 @@
+package features
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yolo-sh/yolo/actions"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/stepper"
+)
+
+type ScheduleInput struct {
+	// Defaults to now
+	At       time.Time
+	PlanMode bool
+}
+
+type ScheduleOutput struct {
+	// Set along with "Content" when some envs failed to be started or stopped
+	Error   error
+	Content *ScheduleOutputContent
+	Stepper stepper.Stepper
+}
+
+type ScheduledEnv struct {
+	Cluster          *entities.Cluster
+	Env              *entities.Env
+	ShouldBeRunning  bool
+	NextTransitionAt time.Time
+	Started          bool
+	Stopped          bool
+	// Errors are reported per env in order to
+	// not block the scheduling of the other ones
+	Error error
+}
+
+type ScheduleOutputContent struct {
+	// Only the envs with a schedule are evaluated
+	ScheduledEnvs []ScheduledEnv
+	// Set only in plan mode
+	Plan *entities.Plan
+}
+
+type ScheduleOutputHandler interface {
+	HandleOutput(ScheduleOutput) error
+}
+
+type ScheduleFeature struct {
+	stepper             stepper.Stepper
+	outputHandler       ScheduleOutputHandler
+	cloudServiceBuilder entities.CloudServiceBuilder
+}
+
+func NewScheduleFeature(
+	stepper stepper.Stepper,
+	outputHandler ScheduleOutputHandler,
+	cloudServiceBuilder entities.CloudServiceBuilder,
+) ScheduleFeature {
+
+	return ScheduleFeature{
+		stepper:             stepper,
+		outputHandler:       outputHandler,
+		cloudServiceBuilder: cloudServiceBuilder,
+	}
+}
+
+func (s ScheduleFeature) Execute(input ScheduleInput) error {
+	handleError := func(err error) error {
+		s.outputHandler.HandleOutput(ScheduleOutput{
+			Stepper: s.stepper,
+			Error:   err,
+		})
+
+		return err
+	}
+
+	s.stepper.StartTemporaryStep("Enforcing the environments schedules")
+
+	cloudService, err := s.cloudServiceBuilder.Build()
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
+	yoloConfig, err := cloudService.LookupYoloConfig(
+		s.stepper,
+	)
+
+	if err != nil {
+		return handleError(err)
+	}
+
+	at := input.At
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	scheduledEnvs := []ScheduledEnv{}
+	failedEnvsCount := 0
+
+	for _, clusterName := range sortedClusterNames(yoloConfig) {
+		cluster := yoloConfig.Clusters[clusterName]
+
+		for _, envName := range sortedEnvNames(cluster) {
+			env := cluster.Envs[envName]
+			schedule := env.GetSchedule(cluster)
+
+			if schedule == nil {
+				continue
+			}
+
+			scheduledEnv := ScheduledEnv{
+				Cluster: cluster,
+				Env:     env,
+			}
+
+			scheduleState, err := schedule.Evaluate(at)
+
+			if err != nil {
+				scheduledEnv.Error = err
+				scheduledEnvs = append(scheduledEnvs, scheduledEnv)
+				failedEnvsCount++
+
+				continue
+			}
+
+			scheduledEnv.ShouldBeRunning = scheduleState.ShouldBeRunning
+			scheduledEnv.NextTransitionAt = scheduleState.NextTransitionAt
+
+			// Envs in other states (eg: creating, removing...)
+			// are left untouched until the next run.
+			// Envs stopped for another reason (eg: expired, idle...)
+			// are not restarted.
+			if scheduleState.ShouldBeRunning &&
+				env.ShouldBeStartedBySchedule(at) {
+
+				s.stepper.StartTemporaryStep(
+					fmt.Sprintf("Starting the environment \"%s\"", env.Name),
+				)
+
+				scheduledEnv.Error = actions.StartEnv(
+					s.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+				)
+
+				scheduledEnv.Started = scheduledEnv.Error == nil
+			}
+
+			if !scheduleState.ShouldBeRunning &&
+				env.Status == entities.EnvStatusCreated {
+
+				s.stepper.StartTemporaryStep(
+					fmt.Sprintf("Stopping the environment \"%s\"", env.Name),
+				)
+
+				scheduledEnv.Error = actions.StopEnv(
+					s.stepper,
+					cloudService,
+					yoloConfig,
+					cluster,
+					env,
+					entities.EnvStopReasonSchedule,
+				)
+
+				scheduledEnv.Stopped = scheduledEnv.Error == nil
+			}
+
+			if scheduledEnv.Error != nil {
+				failedEnvsCount++
+			}
+
+			scheduledEnvs = append(scheduledEnvs, scheduledEnv)
+		}
+	}
+
+	var scheduleErr error
+
+	if failedEnvsCount > 0 {
+		scheduleErr = entities.ErrScheduleEnvs{
+			FailedEnvsCount: failedEnvsCount,
+		}
+	}
+
+	s.outputHandler.HandleOutput(ScheduleOutput{
+		Stepper: s.stepper,
+		Error:   scheduleErr,
+		Content: &ScheduleOutputContent{
+			ScheduledEnvs: scheduledEnvs,
+			Plan:          plan,
+		},
+	})
+
+	return scheduleErr
+}
@@ -11,6 +11,7 @@ import (
 type UninstallInput struct {
 	SuccessMessage            string
 	AlreadyUninstalledMessage string
+	PlanMode                  bool
 }
 
 type UninstallOutput struct {
@@ -23,6 +24,8 @@ type UninstallOutputContent struct {
 	YoloAlreadyUninstalled    bool
 	SuccessMessage            string
 	AlreadyUninstalledMessage string
+	// Set only in plan mode
+	Plan *entities.Plan
 }
 
 type UninstallOutputHandler interface {
@@ -66,6 +69,13 @@ func (u UninstallFeature) Execute(input UninstallInput) error {
 		return handleError(err)
 	}
 
+	var plan *entities.Plan
+
+	if input.PlanMode {
+		plan = entities.NewPlan()
+		cloudService = actions.NewPlanningCloudService(cloudService, plan)
+	}
+
 	yoloConfig, err := cloudService.LookupYoloConfig(
 		u.stepper,
 	)
@@ -131,6 +141,7 @@ func (u UninstallFeature) Execute(input UninstallInput) error {
 			YoloAlreadyUninstalled:    false,
 			SuccessMessage:            input.SuccessMessage,
 			AlreadyUninstalledMessage: input.AlreadyUninstalledMessage,
+			Plan:                      plan,
 		},
 	})
 }
@@ -8,28 +8,58 @@ import (
 
 	giturls "github.com/whilp/git-urls"
 	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/vcs"
 )
 
 func BuildGitHTTPURL(repoOwner, repoName string) entities.EnvRepositoryGitURL {
+	return BuildGitHTTPURLForHost(DefaultHost, repoOwner, repoName)
+}
+
+func BuildGitHTTPURLForHost(
+	host string,
+	repoOwner string,
+	repoName string,
+) entities.EnvRepositoryGitURL {
+
 	return entities.EnvRepositoryGitURL(fmt.Sprintf(
-		"https://github.com/%s/%s.git",
+		"https://%s/%s/%s.git",
+		host,
 		url.PathEscape(repoOwner),
 		url.PathEscape(repoName),
 	))
 }
 
+func (s Service) BuildGitHTTPURL(repoOwner, repoName string) entities.EnvRepositoryGitURL {
+	return BuildGitHTTPURLForHost(s.host, repoOwner, repoName)
+}
+
 func BuildGitURL(repoOwner, repoName string) entities.EnvRepositoryGitURL {
+	return BuildGitURLForHost(DefaultHost, repoOwner, repoName)
+}
+
+func BuildGitURLForHost(
+	host string,
+	repoOwner string,
+	repoName string,
+) entities.EnvRepositoryGitURL {
+
 	return entities.EnvRepositoryGitURL(fmt.Sprintf(
-		"git@github.com:%s/%s.git",
+		"git@%s:%s/%s.git",
+		vcs.HostnameWithoutPort(host),
 		url.PathEscape(repoOwner),
 		url.PathEscape(repoName),
 	))
 }
 
+func (s Service) BuildGitURL(repoOwner, repoName string) entities.EnvRepositoryGitURL {
+	return BuildGitURLForHost(s.host, repoOwner, repoName)
+}
+
 type ParsedGitHubRepositoryName struct {
 	Owner         string
 	ExplicitOwner bool
 	Name          string
+	Variant       string
 }
 
 func ParseRepositoryName(
@@ -37,8 +67,47 @@ func ParseRepositoryName(
 	defaultRepositoryOwner string,
 ) (*ParsedGitHubRepositoryName, error) {
 
+	return ParseRepositoryNameForHost(
+		repositoryName,
+		defaultRepositoryOwner,
+		DefaultHost,
+	)
+}
+
+func (s Service) ParseRepositoryName(
+	repositoryName string,
+	defaultRepositoryOwner string,
+) (*ParsedGitHubRepositoryName, error) {
+
+	return ParseRepositoryNameForHost(
+		repositoryName,
+		defaultRepositoryOwner,
+		s.host,
+	)
+}
+
+// ParseRepositoryNameForHost parses the passed repository name
+// and ensures that URLs target the passed host
+// (eg: "github.com" or a GitHub Enterprise Server host).
+func ParseRepositoryNameForHost(
+	repositoryName string,
+	defaultRepositoryOwner string,
+	expectedHost string,
+) (*ParsedGitHubRepositoryName, error) {
+
 	errInvalidGitHubURL := errors.New("ErrInvalidGitHubURL")
 
+	// Handle yolo-sh/yolo@feature-x
+	repositoryName, variant, hasVariant := vcs.SplitRepositoryVariant(repositoryName)
+
+	if hasVariant {
+		err := entities.CheckEnvRepositoryVariantValidity(variant)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle git@github.com:yolo-sh/yolo.git
 	repositoryNameAsURL, err := giturls.Parse(repositoryName)
 
@@ -60,6 +129,7 @@ func ParseRepositoryName(
 				ExplicitOwner: false,
 				Owner:         defaultRepositoryOwner,
 				Name:          repositoryNameParts[0],
+				Variant:       variant,
 			}, nil
 		}
 
@@ -67,12 +137,13 @@ func ParseRepositoryName(
 			ExplicitOwner: true,
 			Owner:         repositoryNameParts[0],
 			Name:          repositoryNameParts[1],
+			Variant:       variant,
 		}, nil
 	}
 
 	host := repositoryNameAsURL.Hostname()
 
-	if host != "github.com" {
+	if !strings.EqualFold(host, vcs.HostnameWithoutPort(expectedHost)) {
 		return nil, errInvalidGitHubURL
 	}
 
@@ -90,5 +161,6 @@ func ParseRepositoryName(
 		ExplicitOwner: true,
 		Owner:         githubRepositoryOwner,
 		Name:          githubRepositoryName,
+		Variant:       variant,
 	}, nil
 }
@@ -0,0 +1,190 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepositoryName(t *testing.T) {
+	testCases := []struct {
+		test           string
+		repositoryName string
+		expectedParsed *ParsedGitHubRepositoryName
+	}{
+		{
+			test:           "with name only",
+			repositoryName: "yolo",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner: "default-owner",
+				Name:  "yolo",
+			},
+		},
+
+		{
+			test:           "with owner and name",
+			repositoryName: "yolo-sh/yolo",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with git URL",
+			repositoryName: "git@github.com:yolo-sh/yolo.git",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with HTTP URL",
+			repositoryName: "https://github.com/yolo-sh/yolo.git",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with name and variant",
+			repositoryName: "yolo@feature/x",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:   "default-owner",
+				Name:    "yolo",
+				Variant: "feature/x",
+			},
+		},
+
+		{
+			test:           "with owner, name and variant",
+			repositoryName: "yolo-sh/yolo@feature-x",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+				Variant:       "feature-x",
+			},
+		},
+
+		{
+			test:           "with git URL and variant",
+			repositoryName: "git@github.com:yolo-sh/yolo.git@feature-x",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+				Variant:       "feature-x",
+			},
+		},
+
+		{
+			test:           "with HTTP URL and variant",
+			repositoryName: "https://github.com/yolo-sh/yolo@feature-x",
+			expectedParsed: &ParsedGitHubRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+				Variant:       "feature-x",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			parsed, err := ParseRepositoryName(
+				tc.repositoryName,
+				"default-owner",
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedParsed, parsed) {
+				t.Fatalf(
+					"expected parsed repository name to equal '%+v', got '%+v'",
+					tc.expectedParsed,
+					parsed,
+				)
+			}
+		})
+	}
+}
+
+func TestParseInvalidRepositoryName(t *testing.T) {
+	testCases := []struct {
+		test           string
+		repositoryName string
+	}{
+		{
+			test:           "with too many path components",
+			repositoryName: "yolo-sh/yolo/yolo",
+		},
+
+		{
+			test:           "with non GitHub host",
+			repositoryName: "https://gitlab.com/yolo-sh/yolo.git",
+		},
+
+		{
+			test:           "with invalid variant",
+			repositoryName: "yolo-sh/yolo@",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseRepositoryName(
+				tc.repositoryName,
+				"default-owner",
+			)
+
+			if err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+		})
+	}
+}
+
+func TestParseRepositoryNameForEnterpriseHost(t *testing.T) {
+	service := NewService(
+		WithEnterpriseServer("github.example.com", ""),
+	)
+
+	parsed, err := service.ParseRepositoryName(
+		"git@github.example.com:yolo-sh/yolo.git",
+		"default-owner",
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedParsed := &ParsedGitHubRepositoryName{
+		Owner:         "yolo-sh",
+		ExplicitOwner: true,
+		Name:          "yolo",
+	}
+
+	if !reflect.DeepEqual(expectedParsed, parsed) {
+		t.Fatalf(
+			"expected parsed repository name to equal '%+v', got '%+v'",
+			expectedParsed,
+			parsed,
+		)
+	}
+
+	_, err = service.ParseRepositoryName(
+		"https://github.com/yolo-sh/yolo.git",
+		"default-owner",
+	)
+
+	if err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+}
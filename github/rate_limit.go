@@ -0,0 +1,126 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	gogithub "github.com/google/go-github/v43/github"
+)
+
+const (
+	rateLimitMaxRetries = 3
+	// Used when the reset time is already passed (eg: clock skew)
+	rateLimitMinWait = time.Second
+)
+
+// rateLimitTransport handles the primary rate limits (requests per hour)
+// and the secondary ones (abuse detection) returned by GitHub.
+//
+// When the reset time is within the max wait duration, the request is
+// retried once the limit is reset (at most "rateLimitMaxRetries" times).
+// Otherwise, "ErrRateLimited" is returned.
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	maxWait time.Duration
+	minWait time.Duration
+}
+
+func (r rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retries := 0; ; retries++ {
+		resp, err := r.base.RoundTrip(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rateLimitErr, rateLimited := parseRateLimitResponse(resp, time.Now())
+
+		if !rateLimited {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+
+		waitDuration := time.Until(rateLimitErr.ResetAt)
+
+		if waitDuration > r.maxWait || retries >= rateLimitMaxRetries {
+			return nil, rateLimitErr
+		}
+
+		if waitDuration < r.minWait {
+			waitDuration = r.minWait
+		}
+
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return nil, rateLimitErr
+			}
+
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(waitDuration):
+		}
+	}
+}
+
+// parseRateLimitResponse returns false when
+// the passed response is not rate limited.
+func parseRateLimitResponse(resp *http.Response, now time.Time) (ErrRateLimited, bool) {
+	if resp.StatusCode != http.StatusForbidden &&
+		resp.StatusCode != http.StatusTooManyRequests {
+
+		return ErrRateLimited{}, false
+	}
+
+	// Secondary rate limits set the "Retry-After" header
+	if retryAfter := resp.Header.Get("Retry-After"); len(retryAfter) > 0 {
+		retryAfterSeconds, err := strconv.ParseInt(retryAfter, 10, 64)
+
+		if err == nil {
+			return ErrRateLimited{
+				ResetAt:   now.Add(time.Duration(retryAfterSeconds) * time.Second),
+				Secondary: true,
+			}, true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return ErrRateLimited{}, false
+	}
+
+	resetTimestamp, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+
+	if err != nil {
+		return ErrRateLimited{}, false
+	}
+
+	return ErrRateLimited{
+		ResetAt: time.Unix(resetTimestamp, 0),
+	}, true
+}
+
+// IsRateLimitedError also matches the errors returned by the GitHub
+// client itself given that it doesn't send the requests made after a
+// response with no remaining requests until the limit is reset.
+func (s Service) IsRateLimitedError(err error) bool {
+	var rateLimitErr ErrRateLimited
+	var clientRateLimitErr *gogithub.RateLimitError
+	var clientAbuseRateLimitErr *gogithub.AbuseRateLimitError
+
+	return errors.As(err, &rateLimitErr) ||
+		errors.As(err, &clientRateLimitErr) ||
+		errors.As(err, &clientAbuseRateLimitErr)
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+)
+
+// ResponseCache stores the responses returned by
+// the GitHub API to send conditional requests.
+type ResponseCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, response []byte) error
+}
+
+// DiskResponseCache stores each response in
+// its own file in the passed directory.
+type DiskResponseCache struct {
+	directory string
+}
+
+func NewDiskResponseCache(directory string) (DiskResponseCache, error) {
+	err := os.MkdirAll(directory, 0700)
+
+	if err != nil {
+		return DiskResponseCache{}, err
+	}
+
+	return DiskResponseCache{
+		directory: directory,
+	}, nil
+}
+
+func (d DiskResponseCache) Get(key string) ([]byte, bool) {
+	response, err := os.ReadFile(filepath.Join(d.directory, key))
+
+	if err != nil {
+		return nil, false
+	}
+
+	return response, true
+}
+
+func (d DiskResponseCache) Set(key string, response []byte) error {
+	// Written to a temporary file first to never
+	// leave a partially written response in the cache
+	tempFile, err := os.CreateTemp(d.directory, key+".tmp-*")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Write(response)
+	closeErr := tempFile.Close()
+
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), filepath.Join(d.directory, key))
+}
+
+// conditionalRequestTransport sends the ETag of the cached responses in
+// the "If-None-Match" header and returns the cached responses when GitHub
+// answers "304 Not Modified" (these responses don't count against the rate limit).
+type conditionalRequestTransport struct {
+	base  http.RoundTripper
+	cache ResponseCache
+}
+
+// buildResponseCacheKey builds a key that depends on the authorization
+// header given that the responses may differ from one user to another.
+func buildResponseCacheKey(req *http.Request) string {
+	hash := sha256.Sum256([]byte(
+		req.Method + " " + req.URL.String() + " " + req.Header.Get("Authorization"),
+	))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (c conditionalRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.base.RoundTrip(req)
+	}
+
+	cacheKey := buildResponseCacheKey(req)
+	cachedResponse := c.lookupCachedResponse(req, cacheKey)
+
+	if cachedResponse != nil {
+		// The request must not be modified by transports
+		req = req.Clone(req.Context())
+
+		if etag := cachedResponse.Header.Get("ETag"); len(etag) > 0 {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		if lastModified := cachedResponse.Header.Get("Last-Modified"); len(lastModified) > 0 {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := c.base.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cachedResponse != nil {
+		resp.Body.Close()
+
+		// Rate limit headers are up to date in the "304" response
+		for headerName, headerValues := range resp.Header {
+			cachedResponse.Header[headerName] = headerValues
+		}
+
+		return cachedResponse, nil
+	}
+
+	if resp.StatusCode == http.StatusOK &&
+		(len(resp.Header.Get("ETag")) > 0 || len(resp.Header.Get("Last-Modified")) > 0) {
+
+		dumpedResponse, err := httputil.DumpResponse(resp, true)
+
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		// A cache error must not fail the request
+		c.cache.Set(cacheKey, dumpedResponse)
+	}
+
+	return resp, nil
+}
+
+func (c conditionalRequestTransport) lookupCachedResponse(
+	req *http.Request,
+	cacheKey string,
+) *http.Response {
+
+	dumpedResponse, found := c.cache.Get(cacheKey)
+
+	if !found {
+		return nil
+	}
+
+	cachedResponse, err := http.ReadResponse(
+		bufio.NewReader(bytes.NewReader(dumpedResponse)),
+		req,
+	)
+
+	if err != nil {
+		return nil
+	}
+
+	return cachedResponse
+}
@@ -2,28 +2,227 @@ package github
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
 
 	gogithub "github.com/google/go-github/v43/github"
+	"github.com/yolo-sh/yolo/entities"
 	"golang.org/x/oauth2"
 )
 
-type Service struct{}
+// DefaultHost is the host of the public GitHub.
+const DefaultHost = "github.com"
 
-func NewService() Service {
-	return Service{}
+const defaultAPIBaseURL = "https://api.github.com/"
+
+var _ entities.VCSProvider = Service{}
+
+type Service struct {
+	host string
+	// Empty when the public API is used
+	apiBaseURL string
+	// Nil when the access tokens passed to the methods are used
+	tokenSource oauth2.TokenSource
+	// GitHub App installation tokens can't call the
+	// endpoints of the authenticated user (eg: "/user/keys")
+	usesAppInstallationTokens bool
+	// Nil when conditional requests are disabled
+	responseCache    ResponseCache
+	rateLimitMaxWait time.Duration
+	// Nil for the services not built with "NewService"
+	clients *clientPool
+}
+
+// clientPool reuses the clients built
+// for each access token between calls.
+type clientPool struct {
+	mutex   sync.Mutex
+	clients map[string]*gogithub.Client
+}
+
+type ServiceOption func(*Service)
+
+// WithEnterpriseServer configures the service to target
+// a GitHub Enterprise Server instance.
+//
+// The API base URL defaults to "https://<host>/api/v3/" when empty.
+func WithEnterpriseServer(host, apiBaseURL string) ServiceOption {
+	return func(s *Service) {
+		s.host = host
+		s.apiBaseURL = apiBaseURL
+
+		if len(s.apiBaseURL) == 0 {
+			s.apiBaseURL = "https://" + host + "/api/v3/"
+		}
+	}
+}
+
+// WithTokenSource configures the service to authenticate
+// all requests with the user tokens returned by the passed
+// source (see "NewDeviceFlowTokenSource").
+//
+// The access tokens passed to the methods are then ignored.
+func WithTokenSource(tokenSource oauth2.TokenSource) ServiceOption {
+	return func(s *Service) {
+		s.tokenSource = tokenSource
+		s.usesAppInstallationTokens = false
+	}
+}
+
+// WithAppInstallationTokenSource configures the service to authenticate
+// all requests with the GitHub App installation tokens returned by the
+// passed source (see "NewAppInstallationTokenSource").
+//
+// The access tokens passed to the methods are then ignored.
+//
+// The methods that call the endpoints of the authenticated user
+// (user SSH / GPG keys and "GetAuthenticatedUser") return
+// "ErrUserEndpointNotAvailable". Deploy keys must be used instead
+// (see "entities.EnvSSHKeyStrategyDeployKey").
+func WithAppInstallationTokenSource(tokenSource oauth2.TokenSource) ServiceOption {
+	return func(s *Service) {
+		s.tokenSource = tokenSource
+		s.usesAppInstallationTokens = true
+	}
 }
 
-func (s Service) buildClient(accessToken string) *gogithub.Client {
-	oAuthTokenSource := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: accessToken,
+// WithResponseCache enables conditional requests.
+//
+// The responses with an ETag are stored in the passed cache
+// and GitHub is asked to only return the data that has changed.
+func WithResponseCache(responseCache ResponseCache) ServiceOption {
+	return func(s *Service) {
+		s.responseCache = responseCache
+	}
+}
+
+// WithRateLimitWait makes the service wait for the rate limits to be reset
+// when the reset time is within the passed duration. Beyond that,
+// "ErrRateLimited" is returned immediately (the default behavior).
+func WithRateLimitWait(maxWait time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.rateLimitMaxWait = maxWait
+	}
+}
+
+func NewService(options ...ServiceOption) Service {
+	service := Service{
+		host: DefaultHost,
+		clients: &clientPool{
+			clients: map[string]*gogithub.Client{},
 		},
-	)
+	}
+
+	for _, option := range options {
+		option(&service)
+	}
+
+	return service
+}
+
+func (s Service) Host() string {
+	return s.host
+}
+
+// SupportsUserKeys returns false when the service authenticates
+// with GitHub App installation tokens (see "WithAppInstallationTokenSource").
+func (s Service) SupportsUserKeys() bool {
+	return !s.usesAppInstallationTokens
+}
+
+// buildUserClient builds the client used to call
+// the endpoints of the authenticated user.
+func (s Service) buildUserClient(accessToken string) (*gogithub.Client, error) {
+	if s.usesAppInstallationTokens {
+		return nil, ErrUserEndpointNotAvailable{}
+	}
+
+	return s.buildClient(accessToken)
+}
+
+func (s Service) buildClient(accessToken string) (*gogithub.Client, error) {
+	if s.clients == nil {
+		return s.buildNewClient(accessToken)
+	}
+
+	// The access token is ignored when a token source is set
+	clientKey := accessToken
+
+	if s.tokenSource != nil {
+		clientKey = ""
+	}
+
+	s.clients.mutex.Lock()
+	defer s.clients.mutex.Unlock()
+
+	if client, clientExists := s.clients.clients[clientKey]; clientExists {
+		return client, nil
+	}
+
+	client, err := s.buildNewClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.clients.clients[clientKey] = client
 
+	return client, nil
+}
+
+func (s Service) buildNewClient(accessToken string) (*gogithub.Client, error) {
+	oAuthTokenSource := s.tokenSource
+
+	if oAuthTokenSource == nil {
+		oAuthTokenSource = oauth2.StaticTokenSource(
+			&oauth2.Token{
+				AccessToken: accessToken,
+			},
+		)
+	}
+
+	var transport http.RoundTripper = rateLimitTransport{
+		base:    http.DefaultTransport,
+		maxWait: s.rateLimitMaxWait,
+		minWait: rateLimitMinWait,
+	}
+
+	if s.responseCache != nil {
+		transport = conditionalRequestTransport{
+			base:  transport,
+			cache: s.responseCache,
+		}
+	}
+
+	// The OAuth client adds the authorization header
+	// before calling the transports passed in context
 	oAuthClient := oauth2.NewClient(
-		context.TODO(),
+		context.WithValue(
+			context.TODO(),
+			oauth2.HTTPClient,
+			&http.Client{Transport: transport},
+		),
 		oAuthTokenSource,
 	)
 
-	return gogithub.NewClient(oAuthClient)
+	if len(s.apiBaseURL) == 0 {
+		return gogithub.NewClient(oAuthClient), nil
+	}
+
+	apiBaseURL, err := url.Parse(s.apiBaseURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Uploads are served from "<scheme>://<host>/api/uploads/"
+	uploadURL := apiBaseURL.Scheme + "://" + apiBaseURL.Host + "/"
+
+	return gogithub.NewEnterpriseClient(
+		s.apiBaseURL,
+		uploadURL,
+		oAuthClient,
+	)
 }
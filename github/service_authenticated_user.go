@@ -15,7 +15,11 @@ func (s Service) GetAuthenticatedUser(
 	accessToken string,
 ) (*AuthenticatedUser, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
 
 	var primaryEmail string
 	var getPrimaryEmailErr error
@@ -46,11 +50,38 @@ func (s Service) GetAuthenticatedUser(
 	}, nil
 }
 
+// GetAuthenticatedUsername returns an empty username when the
+// service authenticates with GitHub App installation tokens
+// (see "WithAppInstallationTokenSource").
+func (s Service) GetAuthenticatedUsername(accessToken string) (string, error) {
+	if s.usesAppInstallationTokens {
+		return "", nil
+	}
+
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return "", err
+	}
+
+	user, _, err := client.Users.Get(context.TODO(), "")
+
+	if err != nil {
+		return "", err
+	}
+
+	return user.GetLogin(), nil
+}
+
 func (s Service) getAuthenticatedUserPrimaryEmail(
 	accessToken string,
 ) (string, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return "", err
+	}
 
 	emails, _, err := client.Users.ListEmails(context.TODO(), nil)
 
@@ -0,0 +1,222 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestServiceConditionalRequests(t *testing.T) {
+	notModifiedResponses := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/languages", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"languages-etag"` {
+			notModifiedResponses++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"languages-etag"`)
+		json.NewEncoder(w).Encode(map[string]int{
+			"Go": 1000,
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	responseCache, err := NewDiskResponseCache(t.TempDir())
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	service := NewService(
+		WithEnterpriseServer("github.example.com", server.URL+"/api/v3/"),
+		WithResponseCache(responseCache),
+	)
+
+	for i := 0; i < 2; i++ {
+		languages, err := service.GetLanguagesUsedInRepository("access_token", "yolo-sh", "yolo")
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		if len(languages) != 1 || languages[0] != "Go" {
+			t.Fatalf("expected languages to equal '[Go]', got '%+v'", languages)
+		}
+	}
+
+	if notModifiedResponses != 1 {
+		t.Fatalf("expected one '304 Not Modified' response, got '%d'", notModifiedResponses)
+	}
+
+	// The cache is keyed by access token
+	_, err = service.GetLanguagesUsedInRepository("other_access_token", "yolo-sh", "yolo")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if notModifiedResponses != 1 {
+		t.Fatalf("expected cached responses to not be shared between access tokens")
+	}
+}
+
+func TestServiceRateLimits(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	secondaryRateLimitedRequests := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/primary", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/secondary", func(w http.ResponseWriter, r *http.Request) {
+		if secondaryRateLimitedRequests == 0 {
+			secondaryRateLimitedRequests++
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name": "secondary",
+		})
+	})
+
+	lastAllowedRequests := 0
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/last", func(w http.ResponseWriter, r *http.Request) {
+		lastAllowedRequests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name": "last",
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	service := NewService(
+		WithEnterpriseServer("github.example.com", server.URL+"/api/v3/"),
+		WithRateLimitWait(time.Minute),
+	)
+
+	_, err := service.DoesRepositoryExist("access_token", "yolo-sh", "primary")
+
+	if !service.IsRateLimitedError(err) {
+		t.Fatalf("expected rate limited error, got '%+v'", err)
+	}
+
+	var rateLimitErr ErrRateLimited
+	errors.As(err, &rateLimitErr)
+
+	if !rateLimitErr.ResetAt.Equal(resetAt) || rateLimitErr.Secondary {
+		t.Fatalf("expected primary rate limit reset at '%s', got '%+v'", resetAt, rateLimitErr)
+	}
+
+	exists, err := service.DoesRepositoryExist("access_token", "yolo-sh", "secondary")
+
+	if err != nil {
+		t.Fatalf("expected request to be retried once reset, got '%+v'", err)
+	}
+
+	if !exists {
+		t.Fatalf("expected repository to exist")
+	}
+
+	// The last allowed request succeeds...
+	_, err = service.DoesRepositoryExist("access_token", "yolo-sh", "last")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	// ...but the next ones are rejected by the client without being sent
+	_, err = service.DoesRepositoryExist("access_token", "yolo-sh", "last")
+
+	if !service.IsRateLimitedError(err) {
+		t.Fatalf("expected rate limited error, got '%+v'", err)
+	}
+
+	if lastAllowedRequests != 1 {
+		t.Fatalf("expected one request to be sent, got '%d'", lastAllowedRequests)
+	}
+}
+
+func TestRateLimitTransportWithPastResetTime(t *testing.T) {
+	rateLimitedRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rateLimitedRequests++
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: rateLimitTransport{
+			base:    http.DefaultTransport,
+			maxWait: time.Minute,
+			minWait: time.Millisecond,
+		},
+	}
+
+	_, err := client.Get(server.URL)
+
+	var rateLimitErr ErrRateLimited
+
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected rate limited error, got '%+v'", err)
+	}
+
+	if rateLimitedRequests != rateLimitMaxRetries+1 {
+		t.Fatalf(
+			"expected '%d' requests, got '%d'",
+			rateLimitMaxRetries+1,
+			rateLimitedRequests,
+		)
+	}
+}
+
+func TestServiceReusesClients(t *testing.T) {
+	service := NewService()
+
+	client, err := service.buildClient("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	reusedClient, err := service.buildClient("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if client != reusedClient {
+		t.Fatalf("expected client to be reused")
+	}
+
+	otherClient, err := service.buildClient("other_access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if client == otherClient {
+		t.Fatalf("expected clients to not be shared between access tokens")
+	}
+}
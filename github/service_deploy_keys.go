@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v43/github"
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// CreateDeployKey registers the passed key on the repository
+// only, unlike "CreateSSHKey" that registers it on the user account.
+func (s Service) CreateDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	keyPairName string,
+	publicKeyContent string,
+	readOnly bool,
+) (*github.Key, error) {
+
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, err := client.Repositories.CreateKey(
+		context.TODO(),
+		repositoryOwner,
+		repositoryName,
+		&github.Key{
+			Title:    &keyPairName,
+			Key:      &publicKeyContent,
+			ReadOnly: &readOnly,
+		},
+	)
+
+	return key, err
+}
+
+func (s Service) ListDeployKeys(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]*github.Key, error) {
+
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*github.Key{}
+	listOptions := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		keysPage, resp, err := client.Repositories.ListKeys(
+			context.TODO(),
+			repositoryOwner,
+			repositoryName,
+			listOptions,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOptions.Page = resp.NextPage
+	}
+
+	return keys, nil
+}
+
+func (s Service) RemoveDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	deployKeyID int64,
+) error {
+
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Repositories.DeleteKey(
+		context.TODO(),
+		repositoryOwner,
+		repositoryName,
+		deployKeyID,
+	)
+
+	return err
+}
+
+func (s Service) SupportsReadWriteDeployKeys() bool {
+	return true
+}
+
+func (s Service) RegisterDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	keyTitle string,
+	publicKeyContent string,
+	readOnly bool,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateDeployKey(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+		keyTitle,
+		publicKeyContent,
+		readOnly,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVCSKeyFromDeployKey(key), nil
+}
+
+func (s Service) ListRegisteredDeployKeys(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]entities.VCSKey, error) {
+
+	keys, err := s.ListDeployKeys(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, *buildVCSKeyFromDeployKey(key))
+	}
+
+	return vcsKeys, nil
+}
+
+func (s Service) UnregisterDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	keyID string,
+) error {
+
+	deployKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveDeployKey(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+		deployKeyID,
+	)
+}
+
+func buildVCSKeyFromDeployKey(key *github.Key) *entities.VCSKey {
+	return &entities.VCSKey{
+		ID:       strconv.FormatInt(key.GetID(), 10),
+		Title:    key.GetTitle(),
+		ReadOnly: key.GetReadOnly(),
+	}
+}
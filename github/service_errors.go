@@ -1,6 +1,53 @@
 package github
 
-import "github.com/google/go-github/v43/github"
+import (
+	"time"
+
+	"github.com/google/go-github/v43/github"
+)
+
+// ErrRateLimited is returned when a primary or secondary
+// rate limit is reached and the reset time is beyond
+// the max wait duration (see "WithRateLimitWait").
+type ErrRateLimited struct {
+	ResetAt   time.Time
+	Secondary bool
+}
+
+func (ErrRateLimited) Error() string {
+	return "ErrRateLimited"
+}
+
+// ErrUserEndpointNotAvailable is returned when an endpoint of the
+// authenticated user (eg: "/user/keys") is called with a GitHub
+// App installation token given that these tokens act as the App.
+type ErrUserEndpointNotAvailable struct{}
+
+func (ErrUserEndpointNotAvailable) Error() string {
+	return "ErrUserEndpointNotAvailable"
+}
+
+type ErrInvalidAppPrivateKey struct{}
+
+func (ErrInvalidAppPrivateKey) Error() string {
+	return "ErrInvalidAppPrivateKey"
+}
+
+// ErrDeviceFlowExpired is returned when the device
+// code expires before the user has entered it.
+type ErrDeviceFlowExpired struct{}
+
+func (ErrDeviceFlowExpired) Error() string {
+	return "ErrDeviceFlowExpired"
+}
+
+// ErrDeviceFlowAccessDenied is returned when
+// the user cancels the authorization.
+type ErrDeviceFlowAccessDenied struct{}
+
+func (ErrDeviceFlowAccessDenied) Error() string {
+	return "ErrDeviceFlowAccessDenied"
+}
 
 func (s Service) IsNotFoundError(err error) bool {
 	if githubErr, ok := err.(*github.ErrorResponse); ok &&
@@ -21,3 +68,13 @@ func (s Service) IsInvalidAccessTokenError(err error) bool {
 
 	return false
 }
+
+func (s Service) isUnprocessableEntityError(err error) bool {
+	if githubErr, ok := err.(*github.ErrorResponse); ok &&
+		githubErr.Response.StatusCode == 422 {
+
+		return true
+	}
+
+	return false
+}
@@ -2,16 +2,30 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/google/go-github/v43/github"
+	"github.com/yolo-sh/yolo/entities"
 )
 
+// NamedGPGKey adds the "name" field, not
+// supported by the go-github version used, to GPG keys.
+type NamedGPGKey struct {
+	*github.GPGKey
+	Name string `json:"name"`
+}
+
 func (s Service) CreateGPGKey(
 	accessToken string,
 	publicKeyContent string,
 ) (*github.GPGKey, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
 
 	key, _, err := client.Users.CreateGPGKey(
 		context.TODO(),
@@ -21,17 +35,147 @@ func (s Service) CreateGPGKey(
 	return key, err
 }
 
+// CreateNamedGPGKey creates a GPG key with a name that could
+// be used to identify it later (see "ListNamedGPGKeys").
+func (s Service) CreateNamedGPGKey(
+	accessToken string,
+	keyName string,
+	publicKeyContent string,
+) (*NamedGPGKey, error) {
+
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(
+		"POST",
+		"user/gpg_keys",
+		map[string]string{
+			"name":               keyName,
+			"armored_public_key": publicKeyContent,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := &NamedGPGKey{}
+	_, err = client.Do(context.TODO(), req, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s Service) ListNamedGPGKeys(accessToken string) ([]*NamedGPGKey, error) {
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*NamedGPGKey{}
+	page := 1
+
+	for page != 0 {
+		req, err := client.NewRequest(
+			"GET",
+			fmt.Sprintf("user/gpg_keys?per_page=100&page=%d", page),
+			nil,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keysPage := []*NamedGPGKey{}
+		resp, err := client.Do(context.TODO(), req, &keysPage)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage...)
+		page = resp.NextPage
+	}
+
+	return keys, nil
+}
+
 func (s Service) RemoveGPGKey(
 	accessToken string,
 	gpgKeyID int64,
 ) error {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return err
+	}
 
-	_, err := client.Users.DeleteGPGKey(
+	_, err = client.Users.DeleteGPGKey(
 		context.TODO(),
 		gpgKeyID,
 	)
 
 	return err
 }
+
+func (s Service) RegisterGPGKey(
+	accessToken string,
+	keyTitle string,
+	publicKeyContent string,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateNamedGPGKey(accessToken, keyTitle, publicKeyContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVCSKeyFromNamedGPGKey(key), nil
+}
+
+func (s Service) ListRegisteredGPGKeys(accessToken string) ([]entities.VCSKey, error) {
+	keys, err := s.ListNamedGPGKeys(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, *buildVCSKeyFromNamedGPGKey(key))
+	}
+
+	return vcsKeys, nil
+}
+
+func (s Service) UnregisterGPGKey(
+	accessToken string,
+	keyID string,
+) error {
+
+	gpgKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveGPGKey(accessToken, gpgKeyID)
+}
+
+// buildVCSKeyFromNamedGPGKey uses the name as title. The unnamed
+// keys get an empty title so they could never be matched by name.
+func buildVCSKeyFromNamedGPGKey(key *NamedGPGKey) *entities.VCSKey {
+	return &entities.VCSKey{
+		ID:    strconv.FormatInt(key.GetID(), 10),
+		Title: key.Name,
+	}
+}
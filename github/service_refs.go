@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+
+	gogithub "github.com/google/go-github/v43/github"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/vcs"
+)
+
+// ResolveRepositoryRef resolves the passed ref (branch, tag or commit)
+// to a commit SHA. The default branch is used when the ref is empty.
+//
+// Like in Git, tags take precedence over branches with the same name.
+func (s Service) ResolveRepositoryRef(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	ref string,
+) (*entities.ResolvedEnvRepositoryRef, error) {
+
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	errRefNotFound := entities.ErrEnvRepositoryRefNotFound{
+		RepoOwner: repositoryOwner,
+		RepoName:  repositoryName,
+		Ref:       ref,
+	}
+
+	if len(ref) == 0 {
+		repository, _, err := client.Repositories.Get(
+			context.TODO(),
+			repositoryOwner,
+			repositoryName,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ref = repository.GetDefaultBranch()
+		errRefNotFound.Ref = ref
+	}
+
+	// Branches are resolved through the Git references
+	// given that "GetBranch" doesn't return typed errors
+	refTypes := []struct {
+		refType   entities.EnvRepositoryRefType
+		namespace string
+	}{
+		{entities.EnvRepositoryRefTypeTag, "tags/"},
+		{entities.EnvRepositoryRefTypeBranch, "heads/"},
+	}
+
+	for _, refType := range refTypes {
+		commitSHA, err := s.resolveGitRefCommitSHA(
+			client,
+			repositoryOwner,
+			repositoryName,
+			refType.namespace+ref,
+		)
+
+		if err != nil && !s.IsNotFoundError(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			return &entities.ResolvedEnvRepositoryRef{
+				Ref:       ref,
+				RefType:   refType.refType,
+				CommitSHA: commitSHA,
+			}, nil
+		}
+	}
+
+	if !vcs.IsCommitSHA(ref) {
+		return nil, errRefNotFound
+	}
+
+	commitSHA, _, err := client.Repositories.GetCommitSHA1(
+		context.TODO(),
+		repositoryOwner,
+		repositoryName,
+		ref,
+		"",
+	)
+
+	// Invalid or ambiguous SHAs return "422 Unprocessable Entity"
+	if s.IsNotFoundError(err) || s.isUnprocessableEntityError(err) {
+		return nil, errRefNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ResolvedEnvRepositoryRef{
+		Ref:       ref,
+		RefType:   entities.EnvRepositoryRefTypeCommit,
+		CommitSHA: commitSHA,
+	}, nil
+}
+
+func (s Service) resolveGitRefCommitSHA(
+	client *gogithub.Client,
+	repositoryOwner string,
+	repositoryName string,
+	gitRef string,
+) (string, error) {
+
+	resolvedGitRef, _, err := client.Git.GetRef(
+		context.TODO(),
+		repositoryOwner,
+		repositoryName,
+		gitRef,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	object := resolvedGitRef.GetObject()
+
+	// Annotated tags point to tag objects
+	// that may also point to tag objects
+	for object.GetType() == "tag" {
+		annotatedTag, _, err := client.Git.GetTag(
+			context.TODO(),
+			repositoryOwner,
+			repositoryName,
+			object.GetSHA(),
+		)
+
+		if err != nil {
+			return "", err
+		}
+
+		object = annotatedTag.GetObject()
+	}
+
+	return object.GetSHA(), nil
+}
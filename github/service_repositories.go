@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/go-github/v43/github"
+	"github.com/yolo-sh/yolo/entities"
 )
 
 func (s Service) CreateRepository(
@@ -12,7 +13,11 @@ func (s Service) CreateRepository(
 	properties *github.Repository,
 ) (*github.Repository, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
 
 	repository, _, err := client.Repositories.Create(
 		context.TODO(),
@@ -29,7 +34,11 @@ func (s Service) DoesRepositoryExist(
 	repositoryName string,
 ) (bool, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return false, err
+	}
 
 	repository, _, err := client.Repositories.Get(
 		context.TODO(),
@@ -53,16 +62,24 @@ func (s Service) GetFileContentFromRepository(
 	repositoryOwner string,
 	repositoryName string,
 	filePath string,
+	ref string,
 ) (string, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildClient(accessToken)
 
+	if err != nil {
+		return "", err
+	}
+
+	// The default branch is used when the ref is empty
 	fileContent, _, _, err := client.Repositories.GetContents(
 		context.TODO(),
 		repositoryOwner,
 		repositoryName,
 		filePath,
-		nil,
+		&github.RepositoryContentGetOptions{
+			Ref: ref,
+		},
 	)
 
 	if err != nil {
@@ -72,13 +89,38 @@ func (s Service) GetFileContentFromRepository(
 	return fileContent.GetContent()
 }
 
+// GetLanguagesUsedInRepository returns the languages
+// used in the repository, from the most used to the least used.
 func (s Service) GetLanguagesUsedInRepository(
 	accessToken string,
 	repositoryOwner string,
 	repositoryName string,
 ) ([]string, error) {
 
-	client := s.buildClient(accessToken)
+	languages, err := s.GetLanguagesBreakdown(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.RepositoryLanguageNames(languages), nil
+}
+
+func (s Service) GetLanguagesBreakdown(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]entities.RepositoryLanguage, error) {
+
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
 
 	languagesBytes, _, err := client.Repositories.ListLanguages(
 		context.TODO(),
@@ -90,10 +132,73 @@ func (s Service) GetLanguagesUsedInRepository(
 		return nil, err
 	}
 
-	languages := []string{}
-	for language := range languagesBytes {
-		languages = append(languages, language)
+	languagesBytesAsInt64 := map[string]int64{}
+	for language, bytes := range languagesBytes {
+		languagesBytesAsInt64[language] = int64(bytes)
+	}
+
+	return entities.NewRepositoryLanguagesFromBytes(languagesBytesAsInt64), nil
+}
+
+func (s Service) ResolveRepository(
+	accessToken string,
+	repositoryName string,
+	defaultRepositoryOwner string,
+) (*entities.ResolvedEnvRepository, error) {
+
+	parsedRepositoryName, err := s.ParseRepositoryName(
+		repositoryName,
+		defaultRepositoryOwner,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.buildClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repository, _, err := client.Repositories.Get(
+		context.TODO(),
+		parsedRepositoryName.Owner,
+		parsedRepositoryName.Name,
+	)
+
+	if s.IsNotFoundError(err) {
+		return nil, entities.ErrEnvRepositoryNotFound{
+			RepoOwner: parsedRepositoryName.Owner,
+			RepoName:  parsedRepositoryName.Name,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.GetLanguagesBreakdown(
+		accessToken,
+		parsedRepositoryName.Owner,
+		parsedRepositoryName.Name,
+	)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return languages, nil
+	return &entities.ResolvedEnvRepository{
+		Host:          s.host,
+		Name:          parsedRepositoryName.Name,
+		Owner:         parsedRepositoryName.Owner,
+		ExplicitOwner: parsedRepositoryName.ExplicitOwner,
+		GitURL:        s.BuildGitURL(parsedRepositoryName.Owner, parsedRepositoryName.Name),
+		GitHTTPURL:    s.BuildGitHTTPURL(parsedRepositoryName.Owner, parsedRepositoryName.Name),
+		LanguagesUsed: entities.RepositoryLanguageNames(languages),
+		Languages:     languages,
+		// GitHub returns the size in kilobytes
+		SizeBytes: int64(repository.GetSize()) * 1024,
+		Variant:   parsedRepositoryName.Variant,
+	}, nil
 }
@@ -2,8 +2,10 @@ package github
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/google/go-github/v43/github"
+	"github.com/yolo-sh/yolo/entities"
 )
 
 func (s Service) CreateSSHKey(
@@ -12,7 +14,11 @@ func (s Service) CreateSSHKey(
 	publicKeyContent string,
 ) (*github.Key, error) {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
 
 	key, _, err := client.Users.CreateKey(
 		context.TODO(),
@@ -30,12 +36,103 @@ func (s Service) RemoveSSHKey(
 	sshKeyID int64,
 ) error {
 
-	client := s.buildClient(accessToken)
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return err
+	}
 
-	_, err := client.Users.DeleteKey(
+	_, err = client.Users.DeleteKey(
 		context.TODO(),
 		sshKeyID,
 	)
 
 	return err
 }
+
+func (s Service) RegisterSSHKey(
+	accessToken string,
+	keyTitle string,
+	publicKeyContent string,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateSSHKey(accessToken, keyTitle, publicKeyContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.VCSKey{
+		ID:    strconv.FormatInt(key.GetID(), 10),
+		Title: key.GetTitle(),
+	}, nil
+}
+
+func (s Service) UnregisterSSHKey(
+	accessToken string,
+	keyID string,
+) error {
+
+	sshKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveSSHKey(accessToken, sshKeyID)
+}
+
+func (s Service) ListSSHKeys(accessToken string) ([]*github.Key, error) {
+	client, err := s.buildUserClient(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*github.Key{}
+	listOptions := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		// Empty user means the authenticated user
+		keysPage, resp, err := client.Users.ListKeys(
+			context.TODO(),
+			"",
+			listOptions,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOptions.Page = resp.NextPage
+	}
+
+	return keys, nil
+}
+
+func (s Service) ListRegisteredSSHKeys(accessToken string) ([]entities.VCSKey, error) {
+	keys, err := s.ListSSHKeys(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, entities.VCSKey{
+			ID:    strconv.FormatInt(key.GetID(), 10),
+			Title: key.GetTitle(),
+		})
+	}
+
+	return vcsKeys, nil
+}
@@ -0,0 +1,430 @@
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+const (
+	testCommitSHA       = "4f2c1e0d9b8a7c6e5f4d3c2b1a0f9e8d7c6b5a49"
+	testTagCommitSHA    = "9e8d7c6b5a494f2c1e0d9b8a7c6e5f4d3c2b1a0f"
+	testAnnotatedTagSHA = "1a0f9e8d7c6b5a494f2c1e0d9b8a7c6e5f4d3c2b"
+)
+
+func newEnterpriseTestService(t *testing.T) Service {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name":           "yolo",
+			"default_branch": "main",
+		})
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]int{
+			"Go":    1000,
+			"Shell": 100,
+		})
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var key map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&key)
+
+			key["id"] = 42
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(key)
+			return
+		}
+
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": 42, "title": "yolo-key", "read_only": true},
+		})
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/keys/42", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/api/v3/user/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": 1, "title": "yolo-yolo-sh-yolo-key-pair"},
+		})
+	})
+
+	mux.HandleFunc("/api/v3/user/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": 2, "key_id": "3262EFF25BA0D270", "name": "yolo-yolo-sh-yolo-key-pair"},
+			{"id": 3, "key_id": "4262EFF25BA0D270", "name": nil},
+		})
+	})
+
+	// The tag shadows the branch with the same name
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/git/ref/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ref":    "refs/tags/v1.0.0",
+			"object": map[string]string{"type": "tag", "sha": testAnnotatedTagSHA},
+		})
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/git/tags/"+testAnnotatedTagSHA, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sha":    testAnnotatedTagSHA,
+			"object": map[string]string{"type": "commit", "sha": testTagCommitSHA},
+		})
+	})
+
+	for _, branch := range []string{"main", "v1.0.0", "feature/x"} {
+		mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/git/ref/heads/"+branch, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ref":    "refs/heads/main",
+				"object": map[string]string{"type": "commit", "sha": testCommitSHA},
+			})
+		})
+	}
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/commits/4f2c1e0", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testCommitSHA))
+	})
+
+	// Returned for invalid or ambiguous SHAs
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/commits/abcdef0", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "No commit found for SHA: abcdef0",
+		})
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo/contents/.yolo.yml", func(w http.ResponseWriter, r *http.Request) {
+		content := "instance_type: t2.medium"
+
+		if r.URL.Query().Get("ref") == testCommitSHA {
+			content = "instance_type: t2.large"
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	})
+
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"login": "yolo-user",
+			"name":  "Yolo",
+		})
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"message": "Not Found",
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewService(
+		WithEnterpriseServer("github.example.com", server.URL),
+	)
+}
+
+func TestEnterpriseServiceDoesRepositoryExist(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	exists, err := service.DoesRepositoryExist("access_token", "yolo-sh", "yolo")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !exists {
+		t.Fatalf("expected repository to exist")
+	}
+
+	exists, err = service.DoesRepositoryExist("access_token", "yolo-sh", "unknown")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if exists {
+		t.Fatalf("expected repository to not exist")
+	}
+
+	_, err = service.DoesRepositoryExist("invalid_access_token", "yolo-sh", "yolo")
+
+	if !service.IsInvalidAccessTokenError(err) {
+		t.Fatalf("expected invalid access token error, got '%+v'", err)
+	}
+}
+
+func TestEnterpriseServiceGetLanguagesUsedInRepository(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	languages, err := service.GetLanguagesUsedInRepository("access_token", "yolo-sh", "yolo")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	sort.Strings(languages)
+	expectedLanguages := []string{"Go", "Shell"}
+
+	if !reflect.DeepEqual(expectedLanguages, languages) {
+		t.Fatalf(
+			"expected languages to equal '%+v', got '%+v'",
+			expectedLanguages,
+			languages,
+		)
+	}
+}
+
+func TestEnterpriseServiceResolveRepositoryRef(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	testCases := []struct {
+		test        string
+		ref         string
+		expectedRef *entities.ResolvedEnvRepositoryRef
+	}{
+		{
+			test: "with empty ref",
+			ref:  "",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "main",
+				RefType:   entities.EnvRepositoryRefTypeBranch,
+				CommitSHA: testCommitSHA,
+			},
+		},
+
+		{
+			test: "with annotated tag shadowing branch",
+			ref:  "v1.0.0",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "v1.0.0",
+				RefType:   entities.EnvRepositoryRefTypeTag,
+				CommitSHA: testTagCommitSHA,
+			},
+		},
+
+		{
+			test: "with branch containing slash",
+			ref:  "feature/x",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "feature/x",
+				RefType:   entities.EnvRepositoryRefTypeBranch,
+				CommitSHA: testCommitSHA,
+			},
+		},
+
+		{
+			test: "with abbreviated commit SHA",
+			ref:  "4f2c1e0",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "4f2c1e0",
+				RefType:   entities.EnvRepositoryRefTypeCommit,
+				CommitSHA: testCommitSHA,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			resolvedRef, err := service.ResolveRepositoryRef(
+				"access_token",
+				"yolo-sh",
+				"yolo",
+				tc.ref,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedRef, resolvedRef) {
+				t.Fatalf("expected ref to equal '%+v', got '%+v'", tc.expectedRef, resolvedRef)
+			}
+		})
+	}
+
+	// "unknown" is not a SHA, "abcdef0" returns a 422 and "0000000" a 404
+	for _, ref := range []string{"unknown", "abcdef0", "0000000"} {
+		_, err := service.ResolveRepositoryRef(
+			"access_token",
+			"yolo-sh",
+			"yolo",
+			ref,
+		)
+
+		if _, ok := err.(entities.ErrEnvRepositoryRefNotFound); !ok {
+			t.Fatalf("expected ref not found error for '%s', got '%+v'", ref, err)
+		}
+	}
+}
+
+func TestEnterpriseServiceGetFileContentFromRepository(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	testCases := []struct {
+		test            string
+		ref             string
+		expectedContent string
+	}{
+		{
+			test:            "with default branch",
+			ref:             "",
+			expectedContent: "instance_type: t2.medium",
+		},
+
+		{
+			test:            "with pinned commit",
+			ref:             testCommitSHA,
+			expectedContent: "instance_type: t2.large",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			fileContent, err := service.GetFileContentFromRepository(
+				"access_token",
+				"yolo-sh",
+				"yolo",
+				".yolo.yml",
+				tc.ref,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if fileContent != tc.expectedContent {
+				t.Fatalf("expected file content to equal '%s', got '%s'", tc.expectedContent, fileContent)
+			}
+		})
+	}
+}
+
+func TestEnterpriseServiceGetAuthenticatedUsername(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	username, err := service.GetAuthenticatedUsername("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if username != "yolo-user" {
+		t.Fatalf("expected username to equal 'yolo-user', got '%s'", username)
+	}
+}
+
+func TestEnterpriseServiceGitURLs(t *testing.T) {
+	service := NewService(
+		WithEnterpriseServer("github.example.com", ""),
+	)
+
+	gitURL := service.BuildGitURL("yolo-sh", "yolo")
+
+	if gitURL != "git@github.example.com:yolo-sh/yolo.git" {
+		t.Fatalf("expected Git URL to equal 'git@github.example.com:yolo-sh/yolo.git', got '%s'", gitURL)
+	}
+
+	gitHTTPURL := service.BuildGitHTTPURL("yolo-sh", "yolo")
+
+	if gitHTTPURL != "https://github.example.com/yolo-sh/yolo.git" {
+		t.Fatalf("expected Git HTTP URL to equal 'https://github.example.com/yolo-sh/yolo.git', got '%s'", gitHTTPURL)
+	}
+}
+
+func TestEnterpriseServiceDeployKeys(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	key, err := service.RegisterDeployKey(
+		"access_token",
+		"yolo-sh",
+		"yolo",
+		"yolo-key",
+		"ssh-ed25519 AAAA",
+		true,
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedKey := &entities.VCSKey{
+		ID:       "42",
+		Title:    "yolo-key",
+		ReadOnly: true,
+	}
+
+	if !reflect.DeepEqual(expectedKey, key) {
+		t.Fatalf("expected key to equal '%+v', got '%+v'", expectedKey, key)
+	}
+
+	keys, err := service.ListRegisteredDeployKeys("access_token", "yolo-sh", "yolo")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if !reflect.DeepEqual([]entities.VCSKey{*expectedKey}, keys) {
+		t.Fatalf("expected keys to equal '%+v', got '%+v'", []entities.VCSKey{*expectedKey}, keys)
+	}
+
+	err = service.UnregisterDeployKey("access_token", "yolo-sh", "yolo", key.ID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestEnterpriseServiceListRegisteredKeys(t *testing.T) {
+	service := newEnterpriseTestService(t)
+
+	sshKeys, err := service.ListRegisteredSSHKeys("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedSSHKeys := []entities.VCSKey{
+		{ID: "1", Title: "yolo-yolo-sh-yolo-key-pair"},
+	}
+
+	if !reflect.DeepEqual(expectedSSHKeys, sshKeys) {
+		t.Fatalf("expected SSH keys to equal '%+v', got '%+v'", expectedSSHKeys, sshKeys)
+	}
+
+	gpgKeys, err := service.ListRegisteredGPGKeys("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedGPGKeys := []entities.VCSKey{
+		{ID: "2", Title: "yolo-yolo-sh-yolo-key-pair"},
+		{ID: "3", Title: ""},
+	}
+
+	if !reflect.DeepEqual(expectedGPGKeys, gpgKeys) {
+		t.Fatalf("expected GPG keys to equal '%+v', got '%+v'", expectedGPGKeys, gpgKeys)
+	}
+}
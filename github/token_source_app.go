@@ -0,0 +1,204 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// appJWTLifetime is the lifetime of the JWTs used to
+// authenticate as a GitHub App (10 minutes maximum).
+const appJWTLifetime = 9 * time.Minute
+
+// appJWTClockDrift is removed from the JWTs issue time
+// to protect against clock drift between the client and GitHub.
+const appJWTClockDrift = 60 * time.Second
+
+type AppInstallationConfig struct {
+	AppID          int64
+	InstallationID int64
+	// PEM-encoded RSA private key generated in the GitHub App settings
+	PrivateKeyPEM []byte
+	// Empty means that the public API is used
+	APIBaseURL string
+	// Nil means that "http.DefaultClient" is used
+	HTTPClient *http.Client
+}
+
+type appInstallationTokenSource struct {
+	config     AppInstallationConfig
+	privateKey *rsa.PrivateKey
+}
+
+// NewAppInstallationTokenSource returns a token source that
+// exchanges a JWT signed with the GitHub App private key for
+// an installation access token.
+//
+// Installation tokens expire after one hour. They are
+// cached and automatically refreshed once expired.
+//
+// Installation tokens act as the App so they can't
+// be used to manage user keys (see "WithAppInstallationTokenSource").
+func NewAppInstallationTokenSource(
+	config AppInstallationConfig,
+) (oauth2.TokenSource, error) {
+
+	privateKey, err := parseAppPrivateKey(config.PrivateKeyPEM)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.APIBaseURL) == 0 {
+		config.APIBaseURL = defaultAPIBaseURL
+	}
+
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	return oauth2.ReuseTokenSource(nil, appInstallationTokenSource{
+		config:     config,
+		privateKey: privateKey,
+	}), nil
+}
+
+func parseAppPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+
+	if block == nil {
+		return nil, ErrInvalidAppPrivateKey{}
+	}
+
+	// GitHub generates PKCS #1 keys but
+	// PKCS #8 keys are also supported
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, ErrInvalidAppPrivateKey{}
+	}
+
+	privateKey, ok := parsedKey.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, ErrInvalidAppPrivateKey{}
+	}
+
+	return privateKey, nil
+}
+
+// buildAppJWT builds the JWT used to authenticate as the GitHub App.
+// See https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app
+func (a appInstallationTokenSource) buildAppJWT() (string, error) {
+	now := time.Now()
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(a.config.AppID, 10),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsignedJWT := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+
+	hashedJWT := sha256.Sum256([]byte(unsignedJWT))
+
+	signature, err := rsa.SignPKCS1v15(
+		rand.Reader,
+		a.privateKey,
+		crypto.SHA256,
+		hashedJWT[:],
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return unsignedJWT + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (a appInstallationTokenSource) Token() (*oauth2.Token, error) {
+	appJWT, err := a.buildAppJWT()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf(
+			"%s/app/installations/%d/access_tokens",
+			strings.TrimSuffix(a.config.APIBaseURL, "/"),
+			a.config.InstallationID,
+		),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := a.config.HTTPClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf(
+			"unexpected status code (%d) while creating the installation access token",
+			resp.StatusCode,
+		)
+	}
+
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&installationToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: installationToken.Token,
+		TokenType:   "Bearer",
+		Expiry:      installationToken.ExpiresAt,
+	}, nil
+}
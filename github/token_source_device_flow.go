@@ -0,0 +1,228 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// deviceFlowSlowDownInterval is added to the polling
+// interval each time GitHub returns a "slow_down" error.
+const deviceFlowSlowDownInterval = 5 * time.Second
+
+type DeviceFlowConfig struct {
+	ClientID string
+	// Only required to refresh expiring user access tokens
+	ClientSecret string
+	Scopes       []string
+	// Empty means that "github.com" is used
+	Host string
+	// Nil means that "http.DefaultClient" is used
+	HTTPClient *http.Client
+}
+
+// DeviceCode represents the code that the user
+// needs to enter at the verification URI.
+type DeviceCode struct {
+	DeviceCode      string        `json:"device_code"`
+	UserCode        string        `json:"user_code"`
+	VerificationURI string        `json:"verification_uri"`
+	ExpiresIn       time.Duration `json:"-"`
+	Interval        time.Duration `json:"-"`
+}
+
+func (d DeviceFlowConfig) getHost() string {
+	if len(d.Host) == 0 {
+		return DefaultHost
+	}
+
+	return d.Host
+}
+
+func (d DeviceFlowConfig) getHTTPClient() *http.Client {
+	if d.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return d.HTTPClient
+}
+
+func (d DeviceFlowConfig) buildOAuth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     d.ClientID,
+		ClientSecret: d.ClientSecret,
+		Scopes:       d.Scopes,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  "https://" + d.getHost() + "/login/oauth/access_token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (d DeviceFlowConfig) postForm(
+	path string,
+	form url.Values,
+	response interface{},
+) error {
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"https://"+d.getHost()+path,
+		strings.NewReader(form.Encode()),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.getHTTPClient().Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// RequestDeviceCode starts the OAuth device flow.
+// The returned user code needs to be displayed to the user.
+func RequestDeviceCode(config DeviceFlowConfig) (*DeviceCode, error) {
+	var response struct {
+		DeviceCode      string `json:"device_code"`
+		UserCode        string `json:"user_code"`
+		VerificationURI string `json:"verification_uri"`
+		ExpiresIn       int64  `json:"expires_in"`
+		Interval        int64  `json:"interval"`
+		Error           string `json:"error"`
+	}
+
+	err := config.postForm(
+		"/login/device/code",
+		url.Values{
+			"client_id": {config.ClientID},
+			"scope":     {strings.Join(config.Scopes, " ")},
+		},
+		&response,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Error) > 0 {
+		return nil, errors.New(response.Error)
+	}
+
+	return &DeviceCode{
+		DeviceCode:      response.DeviceCode,
+		UserCode:        response.UserCode,
+		VerificationURI: response.VerificationURI,
+		ExpiresIn:       time.Duration(response.ExpiresIn) * time.Second,
+		Interval:        time.Duration(response.Interval) * time.Second,
+	}, nil
+}
+
+// PollDeviceFlowToken waits for the user to enter the
+// passed device code and returns the resulting access token.
+func PollDeviceFlowToken(
+	ctx context.Context,
+	config DeviceFlowConfig,
+	deviceCode *DeviceCode,
+) (*oauth2.Token, error) {
+
+	interval := deviceCode.Interval
+	expiresAt := time.Now().Add(deviceCode.ExpiresIn)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if time.Now().After(expiresAt) {
+			return nil, ErrDeviceFlowExpired{}
+		}
+
+		var response struct {
+			AccessToken  string `json:"access_token"`
+			TokenType    string `json:"token_type"`
+			RefreshToken string `json:"refresh_token"`
+			ExpiresIn    int64  `json:"expires_in"`
+			Error        string `json:"error"`
+			Interval     int64  `json:"interval"`
+		}
+
+		err := config.postForm(
+			"/login/oauth/access_token",
+			url.Values{
+				"client_id":   {config.ClientID},
+				"device_code": {deviceCode.DeviceCode},
+				"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			},
+			&response,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.Error {
+		case "":
+			token := &oauth2.Token{
+				AccessToken:  response.AccessToken,
+				TokenType:    response.TokenType,
+				RefreshToken: response.RefreshToken,
+			}
+
+			// Zero means that the token never expires
+			if response.ExpiresIn > 0 {
+				token.Expiry = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+			}
+
+			return token, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += deviceFlowSlowDownInterval
+
+			if response.Interval > 0 {
+				interval = time.Duration(response.Interval) * time.Second
+			}
+		case "expired_token":
+			return nil, ErrDeviceFlowExpired{}
+		case "access_denied":
+			return nil, ErrDeviceFlowAccessDenied{}
+		default:
+			return nil, errors.New(response.Error)
+		}
+	}
+}
+
+// NewDeviceFlowTokenSource returns a token source that
+// uses the token obtained through the device flow and
+// refreshes it automatically once expired (if expiring).
+func NewDeviceFlowTokenSource(
+	config DeviceFlowConfig,
+	token *oauth2.Token,
+) oauth2.TokenSource {
+
+	ctx := context.WithValue(
+		context.Background(),
+		oauth2.HTTPClient,
+		config.getHTTPClient(),
+	)
+
+	return config.buildOAuth2Config().TokenSource(ctx, token)
+}
@@ -0,0 +1,303 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppInstallationTokenSource(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	tokenExchanges := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v3/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		appJWT := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		jwtParts := strings.Split(appJWT, ".")
+
+		if len(jwtParts) != 3 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		hashedJWT := sha256.Sum256([]byte(jwtParts[0] + "." + jwtParts[1]))
+		signature, _ := base64.RawURLEncoding.DecodeString(jwtParts[2])
+
+		err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, hashedJWT[:], signature)
+
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		claims, _ := base64.RawURLEncoding.DecodeString(jwtParts[1])
+
+		if !strings.Contains(string(claims), `"iss":"1234"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		tokenExchanges++
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "installation_token",
+			"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+		})
+	})
+
+	mux.HandleFunc("/api/v3/repos/yolo-sh/yolo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer installation_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name": "yolo",
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	tokenSource, err := NewAppInstallationTokenSource(AppInstallationConfig{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKeyPEM:  privateKeyPEM,
+		APIBaseURL:     server.URL + "/api/v3/",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	service := NewService(
+		WithEnterpriseServer("github.example.com", server.URL+"/api/v3/"),
+		WithAppInstallationTokenSource(tokenSource),
+	)
+
+	for i := 0; i < 2; i++ {
+		// The passed access token is ignored
+		exists, err := service.DoesRepositoryExist("", "yolo-sh", "yolo")
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+
+		if !exists {
+			t.Fatalf("expected repository to exist")
+		}
+	}
+
+	if tokenExchanges != 1 {
+		t.Fatalf("expected installation token to be reused, got '%d' exchanges", tokenExchanges)
+	}
+
+	// Installation tokens can't call the endpoints of the authenticated user
+	if service.SupportsUserKeys() {
+		t.Fatalf("expected user keys to not be supported")
+	}
+
+	_, err = service.RegisterSSHKey("", "yolo-key", "ssh-ed25519 AAAA")
+
+	if _, ok := err.(ErrUserEndpointNotAvailable); !ok {
+		t.Fatalf("expected user endpoint not available error, got '%+v'", err)
+	}
+
+	_, err = service.ListRegisteredGPGKeys("")
+
+	if _, ok := err.(ErrUserEndpointNotAvailable); !ok {
+		t.Fatalf("expected user endpoint not available error, got '%+v'", err)
+	}
+
+	_, err = service.GetAuthenticatedUser("")
+
+	if _, ok := err.(ErrUserEndpointNotAvailable); !ok {
+		t.Fatalf("expected user endpoint not available error, got '%+v'", err)
+	}
+
+	// The ports opened with installation tokens are not tied to a user
+	username, err := service.GetAuthenticatedUsername("")
+
+	if err != nil || len(username) > 0 {
+		t.Fatalf("expected empty username without error, got '%s' and '%+v'", username, err)
+	}
+
+	if !NewService().SupportsUserKeys() {
+		t.Fatalf("expected user keys to be supported with access tokens")
+	}
+}
+
+func TestAppInstallationTokenSourceWithInvalidPrivateKey(t *testing.T) {
+	_, err := NewAppInstallationTokenSource(AppInstallationConfig{
+		PrivateKeyPEM: []byte("invalid"),
+	})
+
+	if _, ok := err.(ErrInvalidAppPrivateKey); !ok {
+		t.Fatalf("expected invalid private key error, got '%+v'", err)
+	}
+}
+
+func TestDeviceFlow(t *testing.T) {
+	tokenRequests := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login/device/code", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		if r.PostForm.Get("client_id") != "client_id" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "device_code",
+			"user_code":        "ABCD-1234",
+			"verification_uri": "https://github.com/login/device",
+			"expires_in":       900,
+			"interval":         0,
+		})
+	})
+
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		tokenRequests++
+
+		if r.PostForm.Get("device_code") != "device_code" {
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "incorrect_device_code",
+			})
+			return
+		}
+
+		// The user enters the code after the first poll
+		if tokenRequests == 1 {
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "authorization_pending",
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "user_token",
+			"token_type":   "bearer",
+		})
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	config := DeviceFlowConfig{
+		ClientID:   "client_id",
+		Scopes:     []string{"repo"},
+		Host:       strings.TrimPrefix(server.URL, "https://"),
+		HTTPClient: server.Client(),
+	}
+
+	deviceCode, err := RequestDeviceCode(config)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if deviceCode.UserCode != "ABCD-1234" {
+		t.Fatalf("expected user code to equal 'ABCD-1234', got '%s'", deviceCode.UserCode)
+	}
+
+	token, err := PollDeviceFlowToken(context.Background(), config, deviceCode)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if token.AccessToken != "user_token" {
+		t.Fatalf("expected access token to equal 'user_token', got '%s'", token.AccessToken)
+	}
+
+	if tokenRequests != 2 {
+		t.Fatalf("expected token to be polled twice, got '%d' polls", tokenRequests)
+	}
+
+	tokenSource := NewDeviceFlowTokenSource(config, token)
+	sourceToken, err := tokenSource.Token()
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if sourceToken.AccessToken != "user_token" {
+		t.Fatalf("expected access token to equal 'user_token', got '%s'", sourceToken.AccessToken)
+	}
+}
+
+func TestDeviceFlowErrors(t *testing.T) {
+	testCases := []struct {
+		test          string
+		responseError string
+		expectedError interface{}
+	}{
+		{
+			test:          "with expired device code",
+			responseError: "expired_token",
+			expectedError: ErrDeviceFlowExpired{},
+		},
+
+		{
+			test:          "with access denied",
+			responseError: "access_denied",
+			expectedError: ErrDeviceFlowAccessDenied{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			mux := http.NewServeMux()
+
+			mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": tc.responseError,
+				})
+			})
+
+			server := httptest.NewTLSServer(mux)
+			t.Cleanup(server.Close)
+
+			config := DeviceFlowConfig{
+				ClientID:   "client_id",
+				Host:       strings.TrimPrefix(server.URL, "https://"),
+				HTTPClient: server.Client(),
+			}
+
+			_, err := PollDeviceFlowToken(context.Background(), config, &DeviceCode{
+				DeviceCode: "device_code",
+				ExpiresIn:  time.Minute,
+			})
+
+			if reflect.TypeOf(err) != reflect.TypeOf(tc.expectedError) {
+				t.Fatalf("expected error to equal '%+v', got '%+v'", tc.expectedError, err)
+			}
+		})
+	}
+}
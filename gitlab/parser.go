@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	giturls "github.com/whilp/git-urls"
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/vcs"
+)
+
+func BuildGitHTTPURLForHost(
+	host string,
+	repoOwner string,
+	repoName string,
+) entities.EnvRepositoryGitURL {
+
+	return entities.EnvRepositoryGitURL(fmt.Sprintf(
+		"https://%s/%s/%s.git",
+		host,
+		escapeNamespace(repoOwner),
+		url.PathEscape(repoName),
+	))
+}
+
+func (s Service) BuildGitHTTPURL(repoOwner, repoName string) entities.EnvRepositoryGitURL {
+	return BuildGitHTTPURLForHost(s.host, repoOwner, repoName)
+}
+
+func BuildGitURLForHost(
+	host string,
+	repoOwner string,
+	repoName string,
+) entities.EnvRepositoryGitURL {
+
+	return entities.EnvRepositoryGitURL(fmt.Sprintf(
+		"git@%s:%s/%s.git",
+		vcs.HostnameWithoutPort(host),
+		escapeNamespace(repoOwner),
+		url.PathEscape(repoName),
+	))
+}
+
+func (s Service) BuildGitURL(repoOwner, repoName string) entities.EnvRepositoryGitURL {
+	return BuildGitURLForHost(s.host, repoOwner, repoName)
+}
+
+// escapeNamespace escapes each group of the passed
+// namespace (eg: "yolo-sh/backend") but not the separators.
+func escapeNamespace(namespace string) string {
+	groups := strings.Split(namespace, "/")
+
+	for groupIndex, group := range groups {
+		groups[groupIndex] = url.PathEscape(group)
+	}
+
+	return strings.Join(groups, "/")
+}
+
+// ParsedGitLabRepositoryName represents a parsed GitLab project path.
+//
+// Given that GitLab supports nested groups, the owner
+// may contain slashes (eg: "yolo-sh/backend" for "yolo-sh/backend/api").
+type ParsedGitLabRepositoryName struct {
+	Owner         string
+	ExplicitOwner bool
+	Name          string
+	Variant       string
+}
+
+func (s Service) ParseRepositoryName(
+	repositoryName string,
+	defaultRepositoryOwner string,
+) (*ParsedGitLabRepositoryName, error) {
+
+	return ParseRepositoryNameForHost(
+		repositoryName,
+		defaultRepositoryOwner,
+		s.host,
+	)
+}
+
+// ParseRepositoryNameForHost parses the passed repository name
+// and ensures that URLs target the passed host
+// (eg: "gitlab.com" or a self-managed GitLab host).
+func ParseRepositoryNameForHost(
+	repositoryName string,
+	defaultRepositoryOwner string,
+	expectedHost string,
+) (*ParsedGitLabRepositoryName, error) {
+
+	errInvalidGitLabURL := errors.New("ErrInvalidGitLabURL")
+
+	// Handle yolo-sh/yolo@feature-x
+	repositoryName, variant, hasVariant := vcs.SplitRepositoryVariant(repositoryName)
+
+	if hasVariant {
+		err := entities.CheckEnvRepositoryVariantValidity(variant)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Handle git@gitlab.com:yolo-sh/yolo.git
+	repositoryNameAsURL, err := giturls.Parse(repositoryName)
+
+	if err != nil {
+		// Handle https://gitlab.com/yolo-sh/yolo.git
+		repositoryNameAsURL, err = url.Parse(repositoryName)
+	}
+
+	path := repositoryName
+
+	// URL (eg: https://gitlab.com/yolo-sh/yolo.git)
+	if err == nil && len(repositoryNameAsURL.Hostname()) > 0 {
+		host := repositoryNameAsURL.Hostname()
+
+		if !strings.EqualFold(host, vcs.HostnameWithoutPort(expectedHost)) {
+			return nil, errInvalidGitLabURL
+		}
+
+		path = strings.TrimSuffix(
+			strings.Trim(repositoryNameAsURL.Path, "/"),
+			".git",
+		)
+
+		// Handle https://gitlab.com/yolo-sh/yolo/-/tree/main
+		if dashIndex := strings.Index(path, "/-/"); dashIndex != -1 {
+			path = path[:dashIndex]
+		}
+
+		if !strings.Contains(path, "/") {
+			return nil, errInvalidGitLabURL
+		}
+	}
+
+	pathComponents := strings.Split(path, "/")
+
+	for _, pathComponent := range pathComponents {
+		if len(pathComponent) == 0 {
+			return nil, errInvalidGitLabURL
+		}
+	}
+
+	if len(pathComponents) == 1 { // yolo
+		return &ParsedGitLabRepositoryName{
+			ExplicitOwner: false,
+			Owner:         defaultRepositoryOwner,
+			Name:          pathComponents[0],
+			Variant:       variant,
+		}, nil
+	}
+
+	lastComponentIndex := len(pathComponents) - 1
+
+	return &ParsedGitLabRepositoryName{ // yolo-sh/backend/api
+		ExplicitOwner: true,
+		Owner:         strings.Join(pathComponents[:lastComponentIndex], "/"),
+		Name:          pathComponents[lastComponentIndex],
+		Variant:       variant,
+	}, nil
+}
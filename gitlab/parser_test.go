@@ -0,0 +1,132 @@
+package gitlab
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepositoryName(t *testing.T) {
+	testCases := []struct {
+		test           string
+		repositoryName string
+		expectedParsed *ParsedGitLabRepositoryName
+	}{
+		{
+			test:           "with name only",
+			repositoryName: "yolo",
+			expectedParsed: &ParsedGitLabRepositoryName{
+				Owner: "default-owner",
+				Name:  "yolo",
+			},
+		},
+
+		{
+			test:           "with owner and name",
+			repositoryName: "yolo-sh/yolo",
+			expectedParsed: &ParsedGitLabRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with nested groups",
+			repositoryName: "yolo-sh/backend/api",
+			expectedParsed: &ParsedGitLabRepositoryName{
+				Owner:         "yolo-sh/backend",
+				ExplicitOwner: true,
+				Name:          "api",
+			},
+		},
+
+		{
+			test:           "with git URL and nested groups",
+			repositoryName: "git@gitlab.example.com:yolo-sh/backend/api.git",
+			expectedParsed: &ParsedGitLabRepositoryName{
+				Owner:         "yolo-sh/backend",
+				ExplicitOwner: true,
+				Name:          "api",
+			},
+		},
+
+		{
+			test:           "with HTTP URL to a tree",
+			repositoryName: "https://gitlab.example.com/yolo-sh/yolo/-/tree/main",
+			expectedParsed: &ParsedGitLabRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+			},
+		},
+
+		{
+			test:           "with HTTP URL and variant",
+			repositoryName: "https://gitlab.example.com/yolo-sh/yolo.git@feature-x",
+			expectedParsed: &ParsedGitLabRepositoryName{
+				Owner:         "yolo-sh",
+				ExplicitOwner: true,
+				Name:          "yolo",
+				Variant:       "feature-x",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			parsed, err := ParseRepositoryNameForHost(
+				tc.repositoryName,
+				"default-owner",
+				"gitlab.example.com",
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedParsed, parsed) {
+				t.Fatalf(
+					"expected parsed repository name to equal '%+v', got '%+v'",
+					tc.expectedParsed,
+					parsed,
+				)
+			}
+		})
+	}
+}
+
+func TestParseInvalidRepositoryName(t *testing.T) {
+	testCases := []struct {
+		test           string
+		repositoryName string
+	}{
+		{
+			test:           "with non matching host",
+			repositoryName: "https://gitlab.com/yolo-sh/yolo.git",
+		},
+
+		{
+			test:           "with empty path component",
+			repositoryName: "yolo-sh//yolo",
+		},
+
+		{
+			test:           "with invalid variant",
+			repositoryName: "yolo-sh/yolo@",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			_, err := ParseRepositoryNameForHost(
+				tc.repositoryName,
+				"default-owner",
+				"gitlab.example.com",
+			)
+
+			if err == nil {
+				t.Fatalf("expected error, got nothing")
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package gitlab
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type DeployKey struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Key     string `json:"key"`
+	CanPush bool   `json:"can_push"`
+}
+
+// CreateDeployKey registers the passed key on the project
+// only, unlike "CreateSSHKey" that registers it on the user account.
+func (s Service) CreateDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	keyPairName string,
+	publicKeyContent string,
+	readOnly bool,
+) (*DeployKey, error) {
+
+	var key DeployKey
+
+	err := s.do(
+		accessToken,
+		http.MethodPost,
+		projectPath(repositoryOwner, repositoryName)+"/deploy_keys",
+		map[string]interface{}{
+			"title":    keyPairName,
+			"key":      publicKeyContent,
+			"can_push": !readOnly,
+		},
+		&key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s Service) ListDeployKeys(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]DeployKey, error) {
+
+	keys := []DeployKey{}
+
+	for page := 1; ; page++ {
+		keysPage := []DeployKey{}
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			projectPath(repositoryOwner, repositoryName)+
+				"/deploy_keys?per_page="+strconv.Itoa(keysPerPage)+
+				"&page="+strconv.Itoa(page),
+			nil,
+			&keysPage,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage...)
+
+		if len(keysPage) < keysPerPage {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+func (s Service) RemoveDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	deployKeyID int64,
+) error {
+
+	return s.do(
+		accessToken,
+		http.MethodDelete,
+		projectPath(repositoryOwner, repositoryName)+
+			"/deploy_keys/"+strconv.FormatInt(deployKeyID, 10),
+		nil,
+		nil,
+	)
+}
+
+func (s Service) SupportsReadWriteDeployKeys() bool {
+	return true
+}
+
+func (s Service) RegisterDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	keyTitle string,
+	publicKeyContent string,
+	readOnly bool,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateDeployKey(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+		keyTitle,
+		publicKeyContent,
+		readOnly,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buildVCSKeyFromDeployKey(*key), nil
+}
+
+func (s Service) ListRegisteredDeployKeys(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]entities.VCSKey, error) {
+
+	keys, err := s.ListDeployKeys(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, *buildVCSKeyFromDeployKey(key))
+	}
+
+	return vcsKeys, nil
+}
+
+func (s Service) UnregisterDeployKey(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	keyID string,
+) error {
+
+	deployKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveDeployKey(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+		deployKeyID,
+	)
+}
+
+func buildVCSKeyFromDeployKey(key DeployKey) *entities.VCSKey {
+	return &entities.VCSKey{
+		ID:       strconv.FormatInt(key.ID, 10),
+		Title:    key.Title,
+		ReadOnly: !key.CanPush,
+	}
+}
@@ -0,0 +1,58 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ErrorResponse struct {
+	StatusCode int
+	Message    string
+}
+
+func newErrorResponse(statusCode int, body []byte) *ErrorResponse {
+	var decodedBody struct {
+		Message interface{} `json:"message"`
+		Error   string      `json:"error"`
+	}
+
+	message := string(body)
+
+	if err := json.Unmarshal(body, &decodedBody); err == nil {
+		if decodedBody.Message != nil {
+			message = fmt.Sprint(decodedBody.Message)
+		} else if len(decodedBody.Error) > 0 {
+			message = decodedBody.Error
+		}
+	}
+
+	return &ErrorResponse{
+		StatusCode: statusCode,
+		Message:    message,
+	}
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("GitLab API error (%d): %s", e.StatusCode, e.Message)
+}
+
+func (s Service) IsNotFoundError(err error) bool {
+	if gitlabErr, ok := err.(*ErrorResponse); ok &&
+		gitlabErr.StatusCode == http.StatusNotFound {
+
+		return true
+	}
+
+	return false
+}
+
+func (s Service) IsInvalidAccessTokenError(err error) bool {
+	if gitlabErr, ok := err.(*ErrorResponse); ok &&
+		gitlabErr.StatusCode == http.StatusUnauthorized {
+
+		return true
+	}
+
+	return false
+}
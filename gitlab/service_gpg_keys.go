@@ -0,0 +1,132 @@
+package gitlab
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type GPGKey struct {
+	ID  int64  `json:"id"`
+	Key string `json:"key"`
+}
+
+func (s Service) CreateGPGKey(
+	accessToken string,
+	publicKeyContent string,
+) (*GPGKey, error) {
+
+	var key GPGKey
+
+	err := s.do(
+		accessToken,
+		http.MethodPost,
+		"/user/gpg_keys",
+		map[string]string{
+			"key": publicKeyContent,
+		},
+		&key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s Service) RemoveGPGKey(
+	accessToken string,
+	gpgKeyID int64,
+) error {
+
+	return s.do(
+		accessToken,
+		http.MethodDelete,
+		"/user/gpg_keys/"+strconv.FormatInt(gpgKeyID, 10),
+		nil,
+		nil,
+	)
+}
+
+// RegisterGPGKey ignores the passed title
+// given that GitLab doesn't name GPG keys.
+func (s Service) RegisterGPGKey(
+	accessToken string,
+	keyTitle string,
+	publicKeyContent string,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateGPGKey(accessToken, publicKeyContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.VCSKey{
+		ID: strconv.FormatInt(key.ID, 10),
+	}, nil
+}
+
+func (s Service) UnregisterGPGKey(
+	accessToken string,
+	keyID string,
+) error {
+
+	gpgKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveGPGKey(accessToken, gpgKeyID)
+}
+
+func (s Service) ListGPGKeys(accessToken string) ([]GPGKey, error) {
+	keys := []GPGKey{}
+
+	for page := 1; ; page++ {
+		keysPage := []GPGKey{}
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			"/user/gpg_keys?per_page="+strconv.Itoa(keysPerPage)+"&page="+strconv.Itoa(page),
+			nil,
+			&keysPage,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage...)
+
+		if len(keysPage) < keysPerPage {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+// ListRegisteredGPGKeys returns the GPG keys without
+// title given that GitLab doesn't name GPG keys.
+func (s Service) ListRegisteredGPGKeys(accessToken string) ([]entities.VCSKey, error) {
+	keys, err := s.ListGPGKeys(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, entities.VCSKey{
+			ID: strconv.FormatInt(key.ID, 10),
+		})
+	}
+
+	return vcsKeys, nil
+}
@@ -0,0 +1,111 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/yolo-sh/yolo/entities"
+	"github.com/yolo-sh/yolo/vcs"
+)
+
+// Branches and tags share the same structure
+type namedRef struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+type commit struct {
+	ID string `json:"id"`
+}
+
+// ResolveRepositoryRef resolves the passed ref (branch, tag or commit)
+// to a commit SHA. The default branch is used when the ref is empty.
+//
+// Like in Git, tags take precedence over branches with the same name.
+func (s Service) ResolveRepositoryRef(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	ref string,
+) (*entities.ResolvedEnvRepositoryRef, error) {
+
+	if len(ref) == 0 {
+		project, err := s.GetProject(accessToken, repositoryOwner, repositoryName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ref = project.DefaultBranch
+	}
+
+	errRefNotFound := entities.ErrEnvRepositoryRefNotFound{
+		RepoOwner: repositoryOwner,
+		RepoName:  repositoryName,
+		Ref:       ref,
+	}
+
+	refTypes := []struct {
+		refType entities.EnvRepositoryRefType
+		path    string
+	}{
+		{entities.EnvRepositoryRefTypeTag, "/repository/tags/"},
+		{entities.EnvRepositoryRefTypeBranch, "/repository/branches/"},
+	}
+
+	for _, refType := range refTypes {
+		var resolvedRef namedRef
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			projectPath(repositoryOwner, repositoryName)+
+				refType.path+url.PathEscape(ref),
+			nil,
+			&resolvedRef,
+		)
+
+		if err != nil && !s.IsNotFoundError(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			return &entities.ResolvedEnvRepositoryRef{
+				Ref:       ref,
+				RefType:   refType.refType,
+				CommitSHA: resolvedRef.Commit.ID,
+			}, nil
+		}
+	}
+
+	if !vcs.IsCommitSHA(ref) {
+		return nil, errRefNotFound
+	}
+
+	var resolvedCommit commit
+
+	err := s.do(
+		accessToken,
+		http.MethodGet,
+		projectPath(repositoryOwner, repositoryName)+
+			"/repository/commits/"+url.PathEscape(ref),
+		nil,
+		&resolvedCommit,
+	)
+
+	if s.IsNotFoundError(err) {
+		return nil, errRefNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ResolvedEnvRepositoryRef{
+		Ref:       ref,
+		RefType:   entities.EnvRepositoryRefTypeCommit,
+		CommitSHA: resolvedCommit.ID,
+	}, nil
+}
@@ -0,0 +1,212 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type Project struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+	SSHURLToRepo      string `json:"ssh_url_to_repo"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	// Only returned for the users with at least the "Reporter" role
+	Statistics *struct {
+		RepositorySize int64 `json:"repository_size"`
+	} `json:"statistics"`
+}
+
+// projectPath returns the URL-encoded path used to
+// identify the project in the GitLab API (eg: "yolo-sh%2Fyolo").
+func projectPath(repositoryOwner, repositoryName string) string {
+	return "/projects/" + url.PathEscape(repositoryOwner+"/"+repositoryName)
+}
+
+func (s Service) GetProject(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) (*Project, error) {
+
+	var project Project
+
+	err := s.do(
+		accessToken,
+		http.MethodGet,
+		projectPath(repositoryOwner, repositoryName)+"?statistics=true",
+		nil,
+		&project,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+func (s Service) DoesRepositoryExist(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) (bool, error) {
+
+	_, err := s.GetProject(accessToken, repositoryOwner, repositoryName)
+
+	if s.IsNotFoundError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s Service) GetFileContentFromRepository(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+	filePath string,
+	ref string,
+) (string, error) {
+
+	if len(ref) == 0 {
+		project, err := s.GetProject(accessToken, repositoryOwner, repositoryName)
+
+		if err != nil {
+			return "", err
+		}
+
+		ref = project.DefaultBranch
+	}
+
+	fileContent, err := s.doRaw(
+		accessToken,
+		http.MethodGet,
+		projectPath(repositoryOwner, repositoryName)+
+			"/repository/files/"+url.PathEscape(filePath)+
+			"/raw?ref="+url.QueryEscape(ref),
+		nil,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(fileContent), nil
+}
+
+// GetLanguagesUsedInRepository returns the languages
+// used in the repository, from the most used to the least used.
+func (s Service) GetLanguagesUsedInRepository(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]string, error) {
+
+	languages, err := s.GetLanguagesBreakdown(
+		accessToken,
+		repositoryOwner,
+		repositoryName,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.RepositoryLanguageNames(languages), nil
+}
+
+// GetLanguagesBreakdown returns the languages used in the repository.
+// GitLab only returns percentages so the bytes are always zero.
+func (s Service) GetLanguagesBreakdown(
+	accessToken string,
+	repositoryOwner string,
+	repositoryName string,
+) ([]entities.RepositoryLanguage, error) {
+
+	// Languages are returned as percentages (eg: {"Go": 98.5, "Shell": 1.5})
+	languagesPercentages := map[string]float64{}
+
+	err := s.do(
+		accessToken,
+		http.MethodGet,
+		projectPath(repositoryOwner, repositoryName)+"/languages",
+		nil,
+		&languagesPercentages,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewRepositoryLanguagesFromPercentages(languagesPercentages), nil
+}
+
+func (s Service) ResolveRepository(
+	accessToken string,
+	repositoryName string,
+	defaultRepositoryOwner string,
+) (*entities.ResolvedEnvRepository, error) {
+
+	parsedRepositoryName, err := s.ParseRepositoryName(
+		repositoryName,
+		defaultRepositoryOwner,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.GetProject(
+		accessToken,
+		parsedRepositoryName.Owner,
+		parsedRepositoryName.Name,
+	)
+
+	if s.IsNotFoundError(err) {
+		return nil, entities.ErrEnvRepositoryNotFound{
+			RepoOwner: parsedRepositoryName.Owner,
+			RepoName:  parsedRepositoryName.Name,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.GetLanguagesBreakdown(
+		accessToken,
+		parsedRepositoryName.Owner,
+		parsedRepositoryName.Name,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sizeBytes := int64(0)
+
+	if project.Statistics != nil {
+		sizeBytes = project.Statistics.RepositorySize
+	}
+
+	return &entities.ResolvedEnvRepository{
+		Host:          s.host,
+		Name:          parsedRepositoryName.Name,
+		Owner:         parsedRepositoryName.Owner,
+		ExplicitOwner: parsedRepositoryName.ExplicitOwner,
+		GitURL:        s.BuildGitURL(parsedRepositoryName.Owner, parsedRepositoryName.Name),
+		GitHTTPURL:    s.BuildGitHTTPURL(parsedRepositoryName.Owner, parsedRepositoryName.Name),
+		LanguagesUsed: entities.RepositoryLanguageNames(languages),
+		Languages:     languages,
+		SizeBytes:     sizeBytes,
+		Variant:       parsedRepositoryName.Variant,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package gitlab
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type Key struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+func (s Service) CreateSSHKey(
+	accessToken string,
+	keyPairName string,
+	publicKeyContent string,
+) (*Key, error) {
+
+	var key Key
+
+	err := s.do(
+		accessToken,
+		http.MethodPost,
+		"/user/keys",
+		map[string]string{
+			"title": keyPairName,
+			"key":   publicKeyContent,
+		},
+		&key,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (s Service) RemoveSSHKey(
+	accessToken string,
+	sshKeyID int64,
+) error {
+
+	return s.do(
+		accessToken,
+		http.MethodDelete,
+		"/user/keys/"+strconv.FormatInt(sshKeyID, 10),
+		nil,
+		nil,
+	)
+}
+
+func (s Service) SupportsUserKeys() bool {
+	return true
+}
+
+func (s Service) RegisterSSHKey(
+	accessToken string,
+	keyTitle string,
+	publicKeyContent string,
+) (*entities.VCSKey, error) {
+
+	key, err := s.CreateSSHKey(accessToken, keyTitle, publicKeyContent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.VCSKey{
+		ID:    strconv.FormatInt(key.ID, 10),
+		Title: key.Title,
+	}, nil
+}
+
+func (s Service) UnregisterSSHKey(
+	accessToken string,
+	keyID string,
+) error {
+
+	sshKeyID, err := strconv.ParseInt(keyID, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RemoveSSHKey(accessToken, sshKeyID)
+}
+
+const keysPerPage = 100
+
+func (s Service) ListSSHKeys(accessToken string) ([]Key, error) {
+	keys := []Key{}
+
+	for page := 1; ; page++ {
+		keysPage := []Key{}
+
+		err := s.do(
+			accessToken,
+			http.MethodGet,
+			"/user/keys?per_page="+strconv.Itoa(keysPerPage)+"&page="+strconv.Itoa(page),
+			nil,
+			&keysPage,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, keysPage...)
+
+		if len(keysPage) < keysPerPage {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+func (s Service) ListRegisteredSSHKeys(accessToken string) ([]entities.VCSKey, error) {
+	keys, err := s.ListSSHKeys(accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vcsKeys := []entities.VCSKey{}
+
+	for _, key := range keys {
+		vcsKeys = append(vcsKeys, entities.VCSKey{
+			ID:    strconv.FormatInt(key.ID, 10),
+			Title: key.Title,
+		})
+	}
+
+	return vcsKeys, nil
+}
@@ -0,0 +1,327 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+const (
+	testCommitSHA    = "4f2c1e0d9b8a7c6e5f4d3c2b1a0f9e8d7c6b5a49"
+	testTagCommitSHA = "9e8d7c6b5a494f2c1e0d9b8a7c6e5f4d3c2b1a0f"
+)
+
+func newSelfManagedTestService(t *testing.T) Service {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "401 Unauthorized",
+			})
+			return
+		}
+
+		// Project paths are URL-encoded (eg: "yolo-sh%2Fbackend%2Fapi")
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":             1,
+				"name":           "api",
+				"default_branch": "main",
+				"statistics": map[string]interface{}{
+					"repository_size": 2048,
+				},
+			})
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/languages":
+			json.NewEncoder(w).Encode(map[string]float64{
+				"Go":    90.5,
+				"Shell": 9.5,
+			})
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/repository/files/.yolo.yml/raw":
+			switch r.URL.Query().Get("ref") {
+			case "main":
+				w.Write([]byte("instance_type: t2.medium"))
+			case testCommitSHA:
+				w.Write([]byte("instance_type: t2.large"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		// The tag shadows the branch with the same name
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/repository/tags/v1.0.0":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"name":   "v1.0.0",
+				"commit": map[string]string{"id": testTagCommitSHA},
+			})
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/repository/branches/v1.0.0":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"name":   "v1.0.0",
+				"commit": map[string]string{"id": testCommitSHA},
+			})
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/repository/branches/main",
+			"GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/repository/branches/feature%2Fx":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"name":   "main",
+				"commit": map[string]string{"id": testCommitSHA},
+			})
+		case "GET /api/v4/projects/yolo-sh%2Fbackend%2Fapi/repository/commits/4f2c1e0":
+			json.NewEncoder(w).Encode(map[string]string{
+				"id": testCommitSHA,
+			})
+		case "GET /api/v4/user":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":       1,
+				"username": "yolo-user",
+				"name":     "Yolo",
+			})
+		case "POST /api/v4/user/keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":    42,
+				"title": "yolo-key",
+			})
+		case "DELETE /api/v4/user/keys/42":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "404 Project Not Found",
+			})
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewService(
+		WithSelfManagedInstance("gitlab.example.com", server.URL+"/api/v4"),
+	)
+}
+
+func TestSelfManagedServiceResolveRepository(t *testing.T) {
+	service := newSelfManagedTestService(t)
+
+	resolvedRepository, err := service.ResolveRepository(
+		"access_token",
+		"git@gitlab.example.com:yolo-sh/backend/api.git",
+		"default-owner",
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedResolvedRepository := &entities.ResolvedEnvRepository{
+		Host:          "gitlab.example.com",
+		Name:          "api",
+		Owner:         "yolo-sh/backend",
+		ExplicitOwner: true,
+		GitURL:        "git@gitlab.example.com:yolo-sh/backend/api.git",
+		GitHTTPURL:    "https://gitlab.example.com/yolo-sh/backend/api.git",
+		LanguagesUsed: []string{"Go", "Shell"},
+		Languages: []entities.RepositoryLanguage{
+			{Name: "Go", Percentage: 90.5},
+			{Name: "Shell", Percentage: 9.5},
+		},
+		SizeBytes: 2048,
+	}
+
+	if !reflect.DeepEqual(expectedResolvedRepository, resolvedRepository) {
+		t.Fatalf(
+			"expected resolved repository to equal '%+v', got '%+v'",
+			expectedResolvedRepository,
+			resolvedRepository,
+		)
+	}
+
+	_, err = service.ResolveRepository(
+		"access_token",
+		"yolo-sh/unknown",
+		"default-owner",
+	)
+
+	if _, ok := err.(entities.ErrEnvRepositoryNotFound); !ok {
+		t.Fatalf("expected repository not found error, got '%+v'", err)
+	}
+
+	_, err = service.ResolveRepository(
+		"invalid_access_token",
+		"yolo-sh/backend/api",
+		"default-owner",
+	)
+
+	if !service.IsInvalidAccessTokenError(err) {
+		t.Fatalf("expected invalid access token error, got '%+v'", err)
+	}
+}
+
+func TestSelfManagedServiceGetFileContentFromRepository(t *testing.T) {
+	service := newSelfManagedTestService(t)
+
+	fileContent, err := service.GetFileContentFromRepository(
+		"access_token",
+		"yolo-sh/backend",
+		"api",
+		".yolo.yml",
+		"",
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if fileContent != "instance_type: t2.medium" {
+		t.Fatalf("expected file content to equal 'instance_type: t2.medium', got '%s'", fileContent)
+	}
+
+	fileContent, err = service.GetFileContentFromRepository(
+		"access_token",
+		"yolo-sh/backend",
+		"api",
+		".yolo.yml",
+		testCommitSHA,
+	)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if fileContent != "instance_type: t2.large" {
+		t.Fatalf("expected file content to equal 'instance_type: t2.large', got '%s'", fileContent)
+	}
+
+	_, err = service.GetFileContentFromRepository(
+		"access_token",
+		"yolo-sh/backend",
+		"api",
+		"unknown.yml",
+		"",
+	)
+
+	if !service.IsNotFoundError(err) {
+		t.Fatalf("expected not found error, got '%+v'", err)
+	}
+}
+
+func TestSelfManagedServiceResolveRepositoryRef(t *testing.T) {
+	service := newSelfManagedTestService(t)
+
+	testCases := []struct {
+		test        string
+		ref         string
+		expectedRef *entities.ResolvedEnvRepositoryRef
+	}{
+		{
+			test: "with empty ref",
+			ref:  "",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "main",
+				RefType:   entities.EnvRepositoryRefTypeBranch,
+				CommitSHA: testCommitSHA,
+			},
+		},
+
+		{
+			test: "with tag shadowing branch",
+			ref:  "v1.0.0",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "v1.0.0",
+				RefType:   entities.EnvRepositoryRefTypeTag,
+				CommitSHA: testTagCommitSHA,
+			},
+		},
+
+		{
+			test: "with branch containing slash",
+			ref:  "feature/x",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "feature/x",
+				RefType:   entities.EnvRepositoryRefTypeBranch,
+				CommitSHA: testCommitSHA,
+			},
+		},
+
+		{
+			test: "with abbreviated commit SHA",
+			ref:  "4f2c1e0",
+			expectedRef: &entities.ResolvedEnvRepositoryRef{
+				Ref:       "4f2c1e0",
+				RefType:   entities.EnvRepositoryRefTypeCommit,
+				CommitSHA: testCommitSHA,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			resolvedRef, err := service.ResolveRepositoryRef(
+				"access_token",
+				"yolo-sh/backend",
+				"api",
+				tc.ref,
+			)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedRef, resolvedRef) {
+				t.Fatalf("expected ref to equal '%+v', got '%+v'", tc.expectedRef, resolvedRef)
+			}
+		})
+	}
+
+	for _, ref := range []string{"unknown", "abcdef0"} {
+		_, err := service.ResolveRepositoryRef(
+			"access_token",
+			"yolo-sh/backend",
+			"api",
+			ref,
+		)
+
+		if _, ok := err.(entities.ErrEnvRepositoryRefNotFound); !ok {
+			t.Fatalf("expected ref not found error for '%s', got '%+v'", ref, err)
+		}
+	}
+}
+
+func TestSelfManagedServiceSSHKeys(t *testing.T) {
+	service := newSelfManagedTestService(t)
+
+	key, err := service.RegisterSSHKey("access_token", "yolo-key", "ssh-ed25519 AAAA")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedKey := &entities.VCSKey{
+		ID:    "42",
+		Title: "yolo-key",
+	}
+
+	if !reflect.DeepEqual(expectedKey, key) {
+		t.Fatalf("expected key to equal '%+v', got '%+v'", expectedKey, key)
+	}
+
+	err = service.UnregisterSSHKey("access_token", key.ID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+}
+
+func TestSelfManagedServiceGetAuthenticatedUsername(t *testing.T) {
+	service := newSelfManagedTestService(t)
+
+	username, err := service.GetAuthenticatedUsername("access_token")
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if username != "yolo-user" {
+		t.Fatalf("expected username to equal 'yolo-user', got '%s'", username)
+	}
+}
@@ -0,0 +1,145 @@
+package sshconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// maxSymlinksFollowed matches the limit used by "filepath.EvalSymlinks"
+const maxSymlinksFollowed = 255
+
+// WriteManagedSection replaces the managed section
+// of the SSH config at the passed path with the passed host
+// blocks (see "entities.ReplaceSSHConfigManagedSection").
+//
+// The config is created when missing and
+// is not written when its content is unchanged.
+//
+// When the config is a symlink (eg: dotfiles managers),
+// the target file is written and the symlink is kept.
+func WriteManagedSection(
+	configFilePath string,
+	hostBlocks []string,
+) error {
+
+	configFilePath, err := resolveSymlinks(configFilePath)
+
+	if err != nil {
+		return err
+	}
+
+	configContent, err := os.ReadFile(configFilePath)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	fileMode := os.FileMode(0600)
+
+	if err == nil {
+		configFileInfo, err := os.Stat(configFilePath)
+
+		if err != nil {
+			return err
+		}
+
+		fileMode = configFileInfo.Mode().Perm()
+	}
+
+	newConfigContent, err := entities.ReplaceSSHConfigManagedSection(
+		string(configContent),
+		hostBlocks,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if newConfigContent == string(configContent) {
+		return nil
+	}
+
+	configDirectory := filepath.Dir(configFilePath)
+	err = os.MkdirAll(configDirectory, 0700)
+
+	if err != nil {
+		return err
+	}
+
+	// Written in a temporary file first so that
+	// the config is never left partially written
+	tempFile, err := os.CreateTemp(configDirectory, filepath.Base(configFilePath)+".tmp-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.WriteString(newConfigContent)
+
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tempFile.Name(), fileMode)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), configFilePath)
+}
+
+// resolveSymlinks returns the path of the file that the passed path
+// points to. Unlike "filepath.EvalSymlinks", a missing file (or
+// symlink target) is not an error given that it will be created.
+func resolveSymlinks(filePath string) (string, error) {
+	for i := 0; i < maxSymlinksFollowed; i++ {
+		resolvedFilePath, err := filepath.EvalSymlinks(filePath)
+
+		if err == nil {
+			return resolvedFilePath, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		fileInfo, err := os.Lstat(filePath)
+
+		if errors.Is(err, os.ErrNotExist) {
+			return filePath, nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if fileInfo.Mode()&os.ModeSymlink == 0 {
+			return filePath, nil
+		}
+
+		// Dangling symlink, the target is followed manually
+		linkTarget, err := os.Readlink(filePath)
+
+		if err != nil {
+			return "", err
+		}
+
+		if !filepath.IsAbs(linkTarget) {
+			linkTarget = filepath.Join(filepath.Dir(filePath), linkTarget)
+		}
+
+		filePath = linkTarget
+	}
+
+	return "", errors.New("too many links")
+}
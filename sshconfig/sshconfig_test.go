@@ -0,0 +1,126 @@
+package sshconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+func TestWriteManagedSection(t *testing.T) {
+	configFilePath := filepath.Join(t.TempDir(), ".ssh", "config")
+
+	for i := 0; i < 2; i++ {
+		err := WriteManagedSection(configFilePath, []string{"Host a\n  Port 22"})
+
+		if err != nil {
+			t.Fatalf("expected no error, got '%+v'", err)
+		}
+	}
+
+	configContent, err := os.ReadFile(configFilePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	expectedContent := entities.SSHConfigManagedSectionStartMarker + "\n\nHost a\n  Port 22\n\n" +
+		entities.SSHConfigManagedSectionEndMarker + "\n"
+
+	if string(configContent) != expectedContent {
+		t.Fatalf("expected config to equal '%s', got '%s'", expectedContent, configContent)
+	}
+
+	configFileInfo, err := os.Stat(configFilePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got '%+v'", err)
+	}
+
+	if configFileInfo.Mode().Perm() != 0600 {
+		t.Fatalf("expected config mode to equal '0600', got '%o'", configFileInfo.Mode().Perm())
+	}
+}
+
+func TestWriteManagedSectionWithSymlinkedConfig(t *testing.T) {
+	testCases := []struct {
+		test                 string
+		createTargetFile     bool
+		expectedContentStart string
+	}{
+		{
+			test:                 "with existing target",
+			createTargetFile:     true,
+			expectedContentStart: "Host *\n  ServerAliveInterval 60\n\n",
+		},
+
+		{
+			test:                 "with missing target",
+			createTargetFile:     false,
+			expectedContentStart: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			directory := t.TempDir()
+			targetFilePath := filepath.Join(directory, "dotfiles", "ssh_config")
+			configFilePath := filepath.Join(directory, "config")
+
+			err := os.MkdirAll(filepath.Dir(targetFilePath), 0700)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if tc.createTargetFile {
+				err = os.WriteFile(
+					targetFilePath,
+					[]byte("Host *\n  ServerAliveInterval 60\n"),
+					0600,
+				)
+
+				if err != nil {
+					t.Fatalf("expected no error, got '%+v'", err)
+				}
+			}
+
+			err = os.Symlink(filepath.Join("dotfiles", "ssh_config"), configFilePath)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			err = WriteManagedSection(configFilePath, []string{"Host a\n  Port 22"})
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			configFileInfo, err := os.Lstat(configFilePath)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if configFileInfo.Mode()&os.ModeSymlink == 0 {
+				t.Fatalf("expected config to remain a symlink")
+			}
+
+			targetContent, err := os.ReadFile(targetFilePath)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			expectedContent := tc.expectedContentStart +
+				entities.SSHConfigManagedSectionStartMarker + "\n\nHost a\n  Port 22\n\n" +
+				entities.SSHConfigManagedSectionEndMarker + "\n"
+
+			if string(targetContent) != expectedContent {
+				t.Fatalf("expected target to equal '%s', got '%s'", expectedContent, targetContent)
+			}
+		})
+	}
+}
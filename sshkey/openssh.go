@@ -0,0 +1,77 @@
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// See "PROTOCOL.key" in the OpenSSH sources
+const opensshKeyMagic = "openssh-key-v1\x00"
+
+// marshalEd25519PrivateKey encodes the passed key in the
+// unencrypted OpenSSH format given that ed25519 keys
+// don't have a PKCS1 / SEC1 equivalent supported by OpenSSH.
+func marshalEd25519PrivateKey(privateKey ed25519.PrivateKey) (*pem.Block, error) {
+	publicKey, err := ssh.NewPublicKey(privateKey.Public())
+
+	if err != nil {
+		return nil, err
+	}
+
+	checkBytes := make([]byte, 4)
+
+	if _, err := rand.Read(checkBytes); err != nil {
+		return nil, err
+	}
+
+	check := binary.BigEndian.Uint32(checkBytes)
+
+	privateKeyList := struct {
+		Check1     uint32
+		Check2     uint32
+		KeyType    string
+		PublicKey  []byte
+		PrivateKey []byte
+		Comment    string
+		Padding    []byte `ssh:"rest"`
+	}{
+		Check1:     check,
+		Check2:     check,
+		KeyType:    ssh.KeyAlgoED25519,
+		PublicKey:  privateKey.Public().(ed25519.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	// The private key list is padded to the cipher block size
+	// (8 for "none") with the bytes 1, 2, 3...
+	blockSize := 8
+	unpaddedLength := len(ssh.Marshal(privateKeyList))
+
+	for i := 0; (unpaddedLength+i)%blockSize != 0; i++ {
+		privateKeyList.Padding = append(privateKeyList.Padding, byte(i+1))
+	}
+
+	key := struct {
+		CipherName  string
+		KDFName     string
+		KDFOptions  string
+		KeysCount   uint32
+		PublicKey   []byte
+		PrivateKeys []byte
+	}{
+		CipherName:  "none",
+		KDFName:     "none",
+		KeysCount:   1,
+		PublicKey:   publicKey.Marshal(),
+		PrivateKeys: ssh.Marshal(privateKeyList),
+	}
+
+	return &pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte(opensshKeyMagic), ssh.Marshal(key)...),
+	}, nil
+}
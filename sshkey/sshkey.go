@@ -0,0 +1,119 @@
+package sshkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/yolo-sh/yolo/entities"
+	"golang.org/x/crypto/ssh"
+)
+
+var ecdsaCurves = map[int]elliptic.Curve{
+	256: elliptic.P256(),
+	384: elliptic.P384(),
+	521: elliptic.P521(),
+}
+
+// GenerateKeyPair generates a key pair with the passed algorithm.
+// Zero bits means that the default size of the algorithm is used.
+//
+// The private keys are PEM-encoded in the format expected by
+// OpenSSH ("OPENSSH PRIVATE KEY" for ed25519, "EC PRIVATE KEY"
+// for ECDSA and "RSA PRIVATE KEY" for RSA).
+func GenerateKeyPair(
+	algorithm entities.EnvSSHKeyPairAlgorithm,
+	bits int,
+) (*entities.EnvSSHKeyPair, error) {
+
+	err := entities.CheckEnvSSHKeyPairSizeValidity(algorithm, bits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bits == 0 {
+		bits = entities.GetDefaultEnvSSHKeyPairSize(algorithm)
+	}
+
+	var publicKey interface{}
+	var pemBlock *pem.Block
+
+	switch algorithm {
+	case entities.EnvSSHKeyPairAlgorithmEd25519:
+		ed25519PublicKey, ed25519PrivateKey, err := ed25519.GenerateKey(rand.Reader)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pemBlock, err = marshalEd25519PrivateKey(ed25519PrivateKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey = ed25519PublicKey
+	case entities.EnvSSHKeyPairAlgorithmECDSA:
+		ecdsaPrivateKey, err := ecdsa.GenerateKey(ecdsaCurves[bits], rand.Reader)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ecdsaPrivateKeyBytes, err := x509.MarshalECPrivateKey(ecdsaPrivateKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pemBlock = &pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: ecdsaPrivateKeyBytes,
+		}
+
+		publicKey = &ecdsaPrivateKey.PublicKey
+	default: // RSA
+		rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, bits)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pemBlock = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(rsaPrivateKey),
+		}
+
+		publicKey = &rsaPrivateKey.PublicKey
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.EnvSSHKeyPair{
+		Algorithm:  algorithm,
+		Bits:       bits,
+		PEMContent: string(pem.EncodeToMemory(pemBlock)),
+		PublicKeyContent: strings.TrimSpace(
+			string(ssh.MarshalAuthorizedKey(sshPublicKey)),
+		),
+	}, nil
+}
+
+// GenerateEnvKeyPair generates a key pair with the algorithm
+// and the size of the passed env. The env is not updated.
+func GenerateEnvKeyPair(env *entities.Env) (*entities.EnvSSHKeyPair, error) {
+	return GenerateKeyPair(
+		env.GetSSHKeyPairAlgorithm(),
+		env.GetSSHKeyPairBits(),
+	)
+}
@@ -0,0 +1,104 @@
+package sshkey
+
+import (
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	testCases := []struct {
+		test                 string
+		algorithm            entities.EnvSSHKeyPairAlgorithm
+		bits                 int
+		expectedBits         int
+		expectedPublicKeyAlg string
+	}{
+		{
+			test:                 "with ed25519",
+			algorithm:            entities.EnvSSHKeyPairAlgorithmEd25519,
+			expectedBits:         256,
+			expectedPublicKeyAlg: ssh.KeyAlgoED25519,
+		},
+
+		{
+			test:                 "with ECDSA and default size",
+			algorithm:            entities.EnvSSHKeyPairAlgorithmECDSA,
+			expectedBits:         256,
+			expectedPublicKeyAlg: ssh.KeyAlgoECDSA256,
+		},
+
+		{
+			test:                 "with ECDSA and explicit size",
+			algorithm:            entities.EnvSSHKeyPairAlgorithmECDSA,
+			bits:                 384,
+			expectedBits:         384,
+			expectedPublicKeyAlg: ssh.KeyAlgoECDSA384,
+		},
+
+		{
+			test:                 "with RSA and explicit size",
+			algorithm:            entities.EnvSSHKeyPairAlgorithmRSA,
+			bits:                 2048,
+			expectedBits:         2048,
+			expectedPublicKeyAlg: ssh.KeyAlgoRSA,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			keyPair, err := GenerateKeyPair(tc.algorithm, tc.bits)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if keyPair.Algorithm != tc.algorithm {
+				t.Fatalf("expected algorithm to equal '%s', got '%s'", tc.algorithm, keyPair.Algorithm)
+			}
+
+			if keyPair.Bits != tc.expectedBits {
+				t.Fatalf("expected bits to equal '%d', got '%d'", tc.expectedBits, keyPair.Bits)
+			}
+
+			signer, err := ssh.ParsePrivateKey([]byte(keyPair.PEMContent))
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(keyPair.PublicKeyContent))
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if publicKey.Type() != tc.expectedPublicKeyAlg {
+				t.Fatalf(
+					"expected public key type to equal '%s', got '%s'",
+					tc.expectedPublicKeyAlg,
+					publicKey.Type(),
+				)
+			}
+
+			if string(signer.PublicKey().Marshal()) != string(publicKey.Marshal()) {
+				t.Fatalf("expected public key to match private key")
+			}
+		})
+	}
+}
+
+func TestGenerateKeyPairWithInvalidSize(t *testing.T) {
+	_, err := GenerateKeyPair(entities.EnvSSHKeyPairAlgorithmECDSA, 1024)
+
+	if err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+
+	_, err = GenerateKeyPair("dsa", 0)
+
+	if err == nil {
+		t.Fatalf("expected error, got nothing")
+	}
+}
@@ -0,0 +1,90 @@
+package vcs
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	giturls "github.com/whilp/git-urls"
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// ParseRepositoryHost returns the host of the passed repository name
+// (eg: "gitlab.com" for "git@gitlab.com:yolo-sh/yolo.git").
+//
+// An empty host is returned for the repository names
+// that are not URLs (eg: "yolo" or "yolo-sh/yolo").
+func ParseRepositoryHost(repositoryName string) string {
+	repositoryName, _, _ = SplitRepositoryVariant(repositoryName)
+
+	// Handle git@github.com:yolo-sh/yolo.git
+	repositoryNameAsURL, err := giturls.Parse(repositoryName)
+
+	if err != nil {
+		// Handle https://github.com/yolo-sh/yolo.git
+		repositoryNameAsURL, err = url.Parse(repositoryName)
+	}
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(repositoryNameAsURL.Hostname())
+}
+
+// SplitRepositoryVariant splits the variant from the repository name
+// (eg: "yolo-sh/yolo@feature-x" => "yolo-sh/yolo", "feature-x").
+//
+// The user info of URLs (eg: "git@github.com:yolo-sh/yolo.git")
+// is not considered as a variant separator.
+func SplitRepositoryVariant(repositoryName string) (string, string, bool) {
+	variantSearchStart := 0
+
+	if schemeEnd := strings.Index(repositoryName, "://"); schemeEnd != -1 {
+		// eg: https://github.com/yolo-sh/yolo@feature-x
+		hostStart := schemeEnd + len("://")
+		pathStart := strings.Index(repositoryName[hostStart:], "/")
+
+		if pathStart == -1 {
+			return repositoryName, "", false
+		}
+
+		variantSearchStart = hostStart + pathStart
+	} else if hostEnd := strings.Index(repositoryName, ":"); hostEnd != -1 &&
+		!strings.Contains(repositoryName[:hostEnd], "/") {
+
+		// eg: git@github.com:yolo-sh/yolo.git@feature-x
+		variantSearchStart = hostEnd
+	}
+
+	variantSeparatorIndex := strings.Index(
+		repositoryName[variantSearchStart:],
+		entities.EnvRepositoryVariantSeparator,
+	)
+
+	if variantSeparatorIndex == -1 {
+		return repositoryName, "", false
+	}
+
+	variantSeparatorIndex += variantSearchStart
+
+	return repositoryName[:variantSeparatorIndex],
+		repositoryName[variantSeparatorIndex+len(entities.EnvRepositoryVariantSeparator):],
+		true
+}
+
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// IsCommitSHA returns whether the passed ref looks
+// like a full or abbreviated commit SHA.
+func IsCommitSHA(ref string) bool {
+	return commitSHARegexp.MatchString(ref)
+}
+
+// HostnameWithoutPort removes the port from the
+// passed host (eg: "gitlab.local:8443" => "gitlab.local").
+func HostnameWithoutPort(host string) string {
+	hostURL := url.URL{Host: host}
+
+	return hostURL.Hostname()
+}
@@ -0,0 +1,70 @@
+package vcs
+
+import (
+	"strings"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+// Registry dispatches repositories to the VCS provider matching their host.
+//
+// The default provider is used for the repository names
+// that are not URLs (eg: "yolo-sh/yolo") and for the
+// resolved repositories stored without host.
+type Registry struct {
+	defaultProvider entities.VCSProvider
+	providers       map[string]entities.VCSProvider
+}
+
+func NewRegistry(
+	defaultProvider entities.VCSProvider,
+	otherProviders ...entities.VCSProvider,
+) Registry {
+
+	registry := Registry{
+		defaultProvider: defaultProvider,
+		providers:       map[string]entities.VCSProvider{},
+	}
+
+	providers := append(
+		[]entities.VCSProvider{defaultProvider},
+		otherProviders...,
+	)
+
+	for _, provider := range providers {
+		host := strings.ToLower(HostnameWithoutPort(provider.Host()))
+		registry.providers[host] = provider
+	}
+
+	return registry
+}
+
+func (r Registry) GetProviderForHost(host string) (entities.VCSProvider, error) {
+	if len(host) == 0 {
+		return r.defaultProvider, nil
+	}
+
+	provider, providerFound := r.providers[strings.ToLower(HostnameWithoutPort(host))]
+
+	if !providerFound {
+		return nil, entities.ErrVCSProviderNotFound{
+			Host: host,
+		}
+	}
+
+	return provider, nil
+}
+
+func (r Registry) GetProviderForRepository(
+	repositoryName string,
+) (entities.VCSProvider, error) {
+
+	return r.GetProviderForHost(ParseRepositoryHost(repositoryName))
+}
+
+func (r Registry) GetProviderForResolvedRepository(
+	resolvedRepository entities.ResolvedEnvRepository,
+) (entities.VCSProvider, error) {
+
+	return r.GetProviderForHost(resolvedRepository.Host)
+}
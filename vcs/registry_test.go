@@ -0,0 +1,77 @@
+package vcs
+
+import (
+	"testing"
+
+	"github.com/yolo-sh/yolo/entities"
+)
+
+type testVCSProvider struct {
+	entities.VCSProvider
+	host string
+}
+
+func (t testVCSProvider) Host() string {
+	return t.host
+}
+
+func TestRegistryGetProviderForRepository(t *testing.T) {
+	githubProvider := testVCSProvider{host: "github.com"}
+	gitlabProvider := testVCSProvider{host: "gitlab.example.com:8443"}
+
+	registry := NewRegistry(githubProvider, gitlabProvider)
+
+	testCases := []struct {
+		test             string
+		repositoryName   string
+		expectedProvider entities.VCSProvider
+	}{
+		{
+			test:             "with owner and name",
+			repositoryName:   "yolo-sh/yolo",
+			expectedProvider: githubProvider,
+		},
+
+		{
+			test:             "with default provider URL",
+			repositoryName:   "https://github.com/yolo-sh/yolo.git",
+			expectedProvider: githubProvider,
+		},
+
+		{
+			test:             "with other provider git URL",
+			repositoryName:   "git@gitlab.example.com:yolo-sh/backend/api.git",
+			expectedProvider: gitlabProvider,
+		},
+
+		{
+			test:             "with other provider HTTP URL and variant",
+			repositoryName:   "https://GitLab.example.com:8443/yolo-sh/yolo@feature-x",
+			expectedProvider: gitlabProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			provider, err := registry.GetProviderForRepository(tc.repositoryName)
+
+			if err != nil {
+				t.Fatalf("expected no error, got '%+v'", err)
+			}
+
+			if provider != tc.expectedProvider {
+				t.Fatalf(
+					"expected provider to equal '%+v', got '%+v'",
+					tc.expectedProvider,
+					provider,
+				)
+			}
+		})
+	}
+
+	_, err := registry.GetProviderForRepository("https://bitbucket.org/yolo-sh/yolo.git")
+
+	if _, ok := err.(entities.ErrVCSProviderNotFound); !ok {
+		t.Fatalf("expected provider not found error, got '%+v'", err)
+	}
+}